@@ -62,6 +62,130 @@ spec:
             - name: OPERATOR_NAME
               value: "yaks"
 
+`
+	Resources["operator_cluster_role.yaml"] =
+		`
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: yaks-operator
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  - services
+  - endpoints
+  - persistentvolumeclaims
+  - configmaps
+  - secrets
+  - serviceaccounts
+  verbs:
+  - create
+  - delete
+  - deletecollection
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - pods/log
+  - pods/status
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - rbac.authorization.k8s.io
+  resources:
+  - roles
+  - rolebindings
+  verbs:
+  - create
+  - delete
+  - deletecollection
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - events
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - apps
+  resources:
+  - deployments
+  - replicasets
+  - statefulsets
+  verbs:
+  - create
+  - delete
+  - deletecollection
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - monitoring.coreos.com
+  resources:
+  - servicemonitors
+  verbs:
+  - get
+  - create
+- apiGroups:
+  - camel.apache.org
+  resources:
+  - integrations
+  verbs:
+  - create
+  - delete
+  - deletecollection
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - org.citrusframework.yaks
+  resources:
+  - '*'
+  verbs:
+  - '*'
+- apiGroups:
+  - ""
+  resources:
+  - namespaces
+  verbs:
+  - get
+  - list
+  - watch
+
+`
+	Resources["operator_cluster_role_binding.yaml"] =
+		`
+kind: ClusterRoleBinding
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: yaks-operator
+subjects:
+- kind: ServiceAccount
+  name: yaks
+  namespace: yaks
+roleRef:
+  kind: ClusterRole
+  name: yaks-operator
+  apiGroup: rbac.authorization.k8s.io
+
 `
 	Resources["role.yaml"] =
 		`
@@ -330,7 +454,7 @@ spec:
 `
 	Resources["crds/yaks_v1alpha1_test_crd.yaml"] =
 		`
-apiVersion: apiextensions.k8s.io/v1beta1
+apiVersion: apiextensions.k8s.io/v1
 kind: CustomResourceDefinition
 metadata:
   name: tests.org.citrusframework.yaks
@@ -342,104 +466,1353 @@ spec:
     plural: tests
     singular: test
   scope: Namespaced
-  subresources:
-    status: {}
-  additionalPrinterColumns:
-  - name: Phase
-    type: string
-    description: The test phase
-    JSONPath: .status.phase
-  - name: Total
-    type: string
-    description: The total amount of tests
-    JSONPath: .status.results.summary.total
-  - name: Passed
-    type: string
-    description: Passed tests
-    JSONPath: .status.results.summary.passed
-  - name: Failed
-    type: string
-    description: Failed tests
-    JSONPath: .status.results.summary.failed
-  - name: Skipped
-    type: string
-    description: Skipped tests
-    JSONPath: .status.results.summary.skipped
-  - name: Errors
-    type: string
-    description: Test error details
-    priority: 1
-    JSONPath: .status.errors
-  validation:
-    openAPIV3Schema:
-      properties:
-        apiVersion:
-          description: 'APIVersion defines the versioned schema of this representation
-            of an object. Servers should convert recognized schemas to the latest
-            internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources'
-          type: string
-        kind:
-          description: 'Kind is a string value representing the REST resource this
-            object represents. Servers may infer this from the endpoint the client
-            submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds'
-          type: string
-        metadata:
-          type: object
-        spec:
-          properties:
-            source:
-              properties:
-                content:
+  conversion:
+    strategy: None
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+    subresources:
+      status: {}
+    additionalPrinterColumns:
+    - name: Phase
+      type: string
+      description: The test phase
+      jsonPath: .status.phase
+    - name: Total
+      type: integer
+      description: The total amount of tests
+      jsonPath: .status.results.summary.total
+    - name: Passed
+      type: integer
+      description: Passed tests
+      jsonPath: .status.results.summary.passed
+    - name: Failed
+      type: integer
+      description: Failed tests
+      jsonPath: .status.results.summary.failed
+    - name: Skipped
+      type: integer
+      description: Skipped tests
+      jsonPath: .status.results.summary.skipped
+    - name: Error
+      type: string
+      description: The first test error
+      priority: 1
+      jsonPath: .status.errors[0].message
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              source:
+                properties:
+                  content:
+                    type: string
+                  language:
+                    type: string
+                  name:
+                    type: string
+                  parts:
+                    description: Config map keys holding the file content, the parts are concatenated in order
+                    items:
+                      properties:
+                        key:
+                          type: string
+                        name:
+                          type: string
+                        optional:
+                          type: boolean
+                      type: object
+                    type: array
+                type: object
+              sources:
+                description: Additional feature files added to the test
+                items:
+                  properties:
+                    content:
+                      type: string
+                    language:
+                      type: string
+                    name:
+                      type: string
+                    parts:
+                      description: Config map keys holding the file content, the parts are concatenated in order
+                      items:
+                        properties:
+                          key:
+                            type: string
+                          name:
+                            type: string
+                          optional:
+                            type: boolean
+                        type: object
+                      type: array
+                  type: object
+                type: array
+              resources:
+                description: Resource files mounted under the tests path, the name holds the relative path of the file
+                items:
+                  properties:
+                    content:
+                      type: string
+                    name:
+                      type: string
+                    configMap:
+                      description: Config map key holding the resource content
+                      properties:
+                        key:
+                          type: string
+                        name:
+                          type: string
+                        optional:
+                          type: boolean
+                      type: object
+                    secret:
+                      description: Secret key holding the resource content
+                      properties:
+                        key:
+                          type: string
+                        name:
+                          type: string
+                        optional:
+                          type: boolean
+                      type: object
+                    parts:
+                      description: Config map keys holding the file content, the parts are concatenated in order
+                      items:
+                        properties:
+                          key:
+                            type: string
+                          name:
+                            type: string
+                          optional:
+                            type: boolean
+                        type: object
+                      type: array
+                  type: object
+                type: array
+              config:
+                description: Settings file holding the test dependencies and repositories
+                properties:
+                  content:
+                    type: string
+                  name:
+                    type: string
+                  parts:
+                    description: Config map keys holding the file content, the parts are concatenated in order
+                    items:
+                      properties:
+                        key:
+                          type: string
+                        name:
+                          type: string
+                        optional:
+                          type: boolean
+                      type: object
+                    type: array
+                type: object
+              dependencies:
+                description: Maven artifacts added to the test runtime
+                items:
+                  properties:
+                    groupId:
+                      type: string
+                    artifactId:
+                      type: string
+                    version:
+                      type: string
+                  required:
+                  - groupId
+                  - artifactId
+                  - version
+                  type: object
+                type: array
+              repositories:
+                description: Maven repositories used to resolve the test runtime dependencies
+                items:
+                  properties:
+                    id:
+                      type: string
+                    url:
+                      type: string
+                  required:
+                  - id
+                  - url
+                  type: object
+                type: array
+              env:
+                items:
                   type: string
-                language:
+                type: array
+              envVars:
+                description: Environment variables with values read from config map or secret keys
+                items:
+                  type: object
+                  x-kubernetes-preserve-unknown-fields: true
+                type: array
+              envFrom:
+                description: Config maps and secrets imported as environment variables
+                items:
+                  type: object
+                  x-kubernetes-preserve-unknown-fields: true
+                type: array
+              secrets:
+                description: Secrets mounted into the test container
+                items:
+                  properties:
+                    name:
+                      type: string
+                    path:
+                      type: string
+                  required:
+                  - name
+                  type: object
+                type: array
+              configMaps:
+                description: Config maps mounted into the test container
+                items:
+                  properties:
+                    name:
+                      type: string
+                    path:
+                      type: string
+                  required:
+                  - name
+                  type: object
+                type: array
+              timeout:
+                description: Maximum duration of the test run, e.g. 30m
+                type: string
+              dependsOn:
+                description: Names of the tests in the namespace that must pass before this test runs
+                items:
                   type: string
-                name:
+                type: array
+              retries:
+                description: Number of times a failed test is run again
+                type: integer
+              retryBackoff:
+                description: Time to wait before the first retry, doubled with each further retry, e.g. 10s
+                type: string
+              runtime:
+                properties:
+                  image:
+                    description: Container image used to run the test
+                    type: string
+                  imagePullPolicy:
+                    type: string
+                  imagePullSecrets:
+                    items:
+                      properties:
+                        name:
+                          type: string
+                      type: object
+                    type: array
+                  maven:
+                    description: Maven settings used to resolve the test runtime dependencies
+                    properties:
+                      settings:
+                        description: Reference to a settings.xml stored in a config map or secret
+                        type: object
+                        x-kubernetes-preserve-unknown-fields: true
+                      mirror:
+                        description: Repository mirroring all remote repositories
+                        type: string
+                      username:
+                        description: Secret key holding the mirror username
+                        properties:
+                          key:
+                            type: string
+                          name:
+                            type: string
+                          optional:
+                            type: boolean
+                        type: object
+                      password:
+                        description: Secret key holding the mirror password
+                        properties:
+                          key:
+                            type: string
+                          name:
+                            type: string
+                          optional:
+                            type: boolean
+                        type: object
+                    type: object
+                  pod:
+                    description: Customizations merged into the pod running the test
+                    type: object
+                    x-kubernetes-preserve-unknown-fields: true
+                type: object
+            type: object
+          status:
+            properties:
+              phase:
+                type: string
+              results:
+                properties:
+                  duration:
+                    description: Duration of the test run
+                    type: string
+                  summary:
+                    properties:
+                      total:
+                        type: integer
+                      passed:
+                        type: integer
+                      failed:
+                        type: integer
+                      skipped:
+                        type: integer
+                      pending:
+                        type: integer
+                      undefined:
+                        type: integer
+                      flaky:
+                        type: integer
+                    type: object
+                  tests:
+                    items:
+                      properties:
+                        name:
+                          type: string
+                        scenario:
+                          type: string
+                        errorType:
+                          type: string
+                        errorMessage:
+                          type: string
+                        flaky:
+                          type: boolean
+                        skipped:
+                          type: boolean
+                      type: object
+                    type: array
+                  errors:
+                    items:
+                      properties:
+                        test:
+                          type: string
+                        scenario:
+                          type: string
+                        type:
+                          type: string
+                        message:
+                          type: string
+                        location:
+                          type: string
+                      type: object
+                    type: array
+                type: object
+              errors:
+                description: Test errors, tests created by previous versions hold a string
+                x-kubernetes-preserve-unknown-fields: true
+              testID:
+                type: string
+              digest:
+                type: string
+              version:
+                type: string
+              conditions:
+                items:
+                  properties:
+                    type:
+                      type: string
+                    status:
+                      type: string
+                    lastTransitionTime:
+                      format: date-time
+                      type: string
+                    reason:
+                      type: string
+                    message:
+                      type: string
+                  required:
+                  - type
+                  - status
+                  type: object
+                type: array
+              attempts:
+                items:
+                  properties:
+                    testID:
+                      type: string
+                    phase:
+                      type: string
+                    summary:
+                      type: object
+                      x-kubernetes-preserve-unknown-fields: true
+                    errors:
+                      x-kubernetes-preserve-unknown-fields: true
+                    finishedAt:
+                      format: date-time
+                      type: string
+                  type: object
+                type: array
+              retryTime:
+                format: date-time
+                type: string
+            type: object
+        type: object
+  - name: v1beta1
+    served: false
+    storage: false
+    subresources:
+      status: {}
+    additionalPrinterColumns:
+    - name: Phase
+      type: string
+      description: The test phase
+      jsonPath: .status.phase
+    - name: Total
+      type: integer
+      description: The total amount of tests
+      jsonPath: .status.results.summary.total
+    - name: Passed
+      type: integer
+      description: Passed tests
+      jsonPath: .status.results.summary.passed
+    - name: Failed
+      type: integer
+      description: Failed tests
+      jsonPath: .status.results.summary.failed
+    - name: Skipped
+      type: integer
+      description: Skipped tests
+      jsonPath: .status.results.summary.skipped
+    - name: Error
+      type: string
+      description: The first test error
+      priority: 1
+      jsonPath: .status.errors[0].message
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              source:
+                properties:
+                  content:
+                    type: string
+                  language:
+                    type: string
+                  name:
+                    type: string
+                  parts:
+                    description: Config map keys holding the file content, the parts are concatenated in order
+                    items:
+                      properties:
+                        key:
+                          type: string
+                        name:
+                          type: string
+                        optional:
+                          type: boolean
+                      type: object
+                    type: array
+                type: object
+              sources:
+                description: Additional feature files added to the test
+                items:
+                  properties:
+                    content:
+                      type: string
+                    language:
+                      type: string
+                    name:
+                      type: string
+                    parts:
+                      description: Config map keys holding the file content, the parts are concatenated in order
+                      items:
+                        properties:
+                          key:
+                            type: string
+                          name:
+                            type: string
+                          optional:
+                            type: boolean
+                        type: object
+                      type: array
+                  type: object
+                type: array
+              resources:
+                description: Resource files mounted under the tests path, the name holds the relative path of the file
+                items:
+                  properties:
+                    content:
+                      type: string
+                    name:
+                      type: string
+                    configMap:
+                      description: Config map key holding the resource content
+                      properties:
+                        key:
+                          type: string
+                        name:
+                          type: string
+                        optional:
+                          type: boolean
+                      type: object
+                    secret:
+                      description: Secret key holding the resource content
+                      properties:
+                        key:
+                          type: string
+                        name:
+                          type: string
+                        optional:
+                          type: boolean
+                      type: object
+                    parts:
+                      description: Config map keys holding the file content, the parts are concatenated in order
+                      items:
+                        properties:
+                          key:
+                            type: string
+                          name:
+                            type: string
+                          optional:
+                            type: boolean
+                        type: object
+                      type: array
+                  type: object
+                type: array
+              settings:
+                description: Settings file holding the test dependencies and repositories
+                properties:
+                  content:
+                    type: string
+                  name:
+                    type: string
+                  parts:
+                    description: Config map keys holding the file content, the parts are concatenated in order
+                    items:
+                      properties:
+                        key:
+                          type: string
+                        name:
+                          type: string
+                        optional:
+                          type: boolean
+                      type: object
+                    type: array
+                type: object
+              dependencies:
+                description: Maven artifacts added to the test runtime
+                items:
+                  properties:
+                    groupId:
+                      type: string
+                    artifactId:
+                      type: string
+                    version:
+                      type: string
+                  required:
+                  - groupId
+                  - artifactId
+                  - version
+                  type: object
+                type: array
+              repositories:
+                description: Maven repositories used to resolve the test runtime dependencies
+                items:
+                  properties:
+                    id:
+                      type: string
+                    url:
+                      type: string
+                  required:
+                  - id
+                  - url
+                  type: object
+                type: array
+              env:
+                description: Environment variables of the test, values may be read from config map or secret keys
+                items:
+                  properties:
+                    name:
+                      type: string
+                    value:
+                      type: string
+                    valueFrom:
+                      type: object
+                      x-kubernetes-preserve-unknown-fields: true
+                  required:
+                  - name
+                  type: object
+                type: array
+              envFrom:
+                description: Config maps and secrets imported as environment variables
+                items:
+                  type: object
+                  x-kubernetes-preserve-unknown-fields: true
+                type: array
+              secrets:
+                description: Secrets mounted into the test container
+                items:
+                  properties:
+                    name:
+                      type: string
+                    path:
+                      type: string
+                  required:
+                  - name
+                  type: object
+                type: array
+              configMaps:
+                description: Config maps mounted into the test container
+                items:
+                  properties:
+                    name:
+                      type: string
+                    path:
+                      type: string
+                  required:
+                  - name
+                  type: object
+                type: array
+              timeout:
+                description: Maximum duration of the test run, e.g. 30m
+                type: string
+              dependsOn:
+                description: Names of the tests in the namespace that must pass before this test runs
+                items:
                   type: string
-              type: object
-          type: object
-        status:
-          properties:
-            phase:
-              type: string
-            results:
-              properties:
-                summary:
+                type: array
+              retries:
+                description: Number of times a failed test is run again
+                type: integer
+              retryBackoff:
+                description: Time to wait before the first retry, doubled with each further retry, e.g. 10s
+                type: string
+              runtime:
+                properties:
+                  image:
+                    description: Container image used to run the test
+                    type: string
+                  imagePullPolicy:
+                    type: string
+                  imagePullSecrets:
+                    items:
+                      properties:
+                        name:
+                          type: string
+                      type: object
+                    type: array
+                  maven:
+                    description: Maven settings used to resolve the test runtime dependencies
+                    properties:
+                      settings:
+                        description: Reference to a settings.xml stored in a config map or secret
+                        type: object
+                        x-kubernetes-preserve-unknown-fields: true
+                      mirror:
+                        description: Repository mirroring all remote repositories
+                        type: string
+                      username:
+                        description: Secret key holding the mirror username
+                        properties:
+                          key:
+                            type: string
+                          name:
+                            type: string
+                          optional:
+                            type: boolean
+                        type: object
+                      password:
+                        description: Secret key holding the mirror password
+                        properties:
+                          key:
+                            type: string
+                          name:
+                            type: string
+                          optional:
+                            type: boolean
+                        type: object
+                    type: object
+                  pod:
+                    description: Customizations merged into the pod running the test
+                    type: object
+                    x-kubernetes-preserve-unknown-fields: true
+                type: object
+            type: object
+          status:
+            properties:
+              phase:
+                type: string
+              results:
+                properties:
+                  duration:
+                    description: Duration of the test run
+                    type: string
+                  summary:
+                    properties:
+                      total:
+                        type: integer
+                      passed:
+                        type: integer
+                      failed:
+                        type: integer
+                      skipped:
+                        type: integer
+                      pending:
+                        type: integer
+                      undefined:
+                        type: integer
+                      flaky:
+                        type: integer
+                    type: object
+                  tests:
+                    items:
+                      properties:
+                        name:
+                          type: string
+                        scenario:
+                          type: string
+                        errorType:
+                          type: string
+                        errorMessage:
+                          type: string
+                        flaky:
+                          type: boolean
+                        skipped:
+                          type: boolean
+                      type: object
+                    type: array
+                  errors:
+                    items:
+                      properties:
+                        test:
+                          type: string
+                        scenario:
+                          type: string
+                        type:
+                          type: string
+                        message:
+                          type: string
+                        location:
+                          type: string
+                      type: object
+                    type: array
+                type: object
+              errors:
+                items:
                   properties:
-                    total:
-                      type: number
-                    passed:
-                      type: number
-                    failed:
-                      type: number
-                    skipped:
-                      type: number
-                    pending:
-                      type: number
-                    undefined:
-                      type: number
+                    test:
+                      type: string
+                    scenario:
+                      type: string
+                    type:
+                      type: string
+                    message:
+                      type: string
+                    location:
+                      type: string
                   type: object
-                tests:
-                  items:
+                type: array
+              testID:
+                type: string
+              digest:
+                type: string
+              version:
+                type: string
+              conditions:
+                items:
+                  properties:
+                    type:
+                      type: string
+                    status:
+                      type: string
+                    lastTransitionTime:
+                      format: date-time
+                      type: string
+                    reason:
+                      type: string
+                    message:
+                      type: string
+                  required:
+                  - type
+                  - status
+                  type: object
+                type: array
+              attempts:
+                items:
+                  properties:
+                    testID:
+                      type: string
+                    phase:
+                      type: string
+                    summary:
+                      type: object
+                      x-kubernetes-preserve-unknown-fields: true
+                    errors:
+                      items:
+                        properties:
+                          test:
+                            type: string
+                          scenario:
+                            type: string
+                          type:
+                            type: string
+                          message:
+                            type: string
+                          location:
+                            type: string
+                        type: object
+                      type: array
+                    finishedAt:
+                      format: date-time
+                      type: string
+                  type: object
+                type: array
+              retryTime:
+                format: date-time
+                type: string
+            type: object
+        type: object
+
+`
+	Resources["crds/yaks_v1alpha1_testschedule_cr.yaml"] =
+		`
+apiVersion: org.citrusframework.yaks/v1alpha1
+kind: TestSchedule
+metadata:
+  name: example-schedule
+spec:
+  schedule: "*/15 * * * *"
+  concurrencyPolicy: Forbid
+  template:
+    spec:
+      source:
+        name: smoke.feature
+        language: feature
+        content: |-
+          Feature: smoke
+
+            Scenario: print slogan
+              Given print 'YAKS rocks!'
+
+`
+	Resources["crds/yaks_v1alpha1_testschedule_crd.yaml"] =
+		`
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: testschedules.org.citrusframework.yaks
+spec:
+  group: org.citrusframework.yaks
+  names:
+    kind: TestSchedule
+    listKind: TestScheduleList
+    plural: testschedules
+    singular: testschedule
+  scope: Namespaced
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+    subresources:
+      status: {}
+    additionalPrinterColumns:
+    - name: Schedule
+      type: string
+      description: The cron schedule
+      jsonPath: .spec.schedule
+    - name: Last Schedule
+      type: date
+      description: The time the last run was scheduled
+      jsonPath: .status.lastScheduleTime
+    - name: Last Result
+      type: string
+      description: The phase of the last finished run
+      jsonPath: .status.lastResult
+    - name: Last Run
+      type: string
+      description: The last test run
+      priority: 1
+      jsonPath: .status.lastRun
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              schedule:
+                description: The schedule in cron format, evaluated in UTC
+                type: string
+              template:
+                description: The test created for each scheduled run
+                properties:
+                  labels:
+                    additionalProperties:
+                      type: string
                     type: object
+                  spec:
                     properties:
-                      name:
-                        type: string
-                      errorType:
+                      source:
+                        properties:
+                          content:
+                            type: string
+                          language:
+                            type: string
+                          name:
+                            type: string
+                        type: object
+                      sources:
+                        description: Additional feature files added to the test
+                        items:
+                          properties:
+                            content:
+                              type: string
+                            language:
+                              type: string
+                            name:
+                              type: string
+                          type: object
+                        type: array
+                      resources:
+                        description: Resource files mounted under the tests path, the name holds the relative path of the file
+                        items:
+                          properties:
+                            content:
+                              type: string
+                            name:
+                              type: string
+                            configMap:
+                              description: Config map key holding the resource content
+                              properties:
+                                key:
+                                  type: string
+                                name:
+                                  type: string
+                                optional:
+                                  type: boolean
+                              type: object
+                            secret:
+                              description: Secret key holding the resource content
+                              properties:
+                                key:
+                                  type: string
+                                name:
+                                  type: string
+                                optional:
+                                  type: boolean
+                              type: object
+                          type: object
+                        type: array
+                      config:
+                        description: Settings file holding the test dependencies and repositories
+                        properties:
+                          content:
+                            type: string
+                          name:
+                            type: string
+                        type: object
+                      dependencies:
+                        description: Maven artifacts added to the test runtime
+                        items:
+                          properties:
+                            groupId:
+                              type: string
+                            artifactId:
+                              type: string
+                            version:
+                              type: string
+                          required:
+                          - groupId
+                          - artifactId
+                          - version
+                          type: object
+                        type: array
+                      repositories:
+                        description: Maven repositories used to resolve the test runtime dependencies
+                        items:
+                          properties:
+                            id:
+                              type: string
+                            url:
+                              type: string
+                          required:
+                          - id
+                          - url
+                          type: object
+                        type: array
+                      env:
+                        items:
+                          type: string
+                        type: array
+                      envVars:
+                        description: Environment variables with values read from config map or secret keys
+                        items:
+                          type: object
+                          x-kubernetes-preserve-unknown-fields: true
+                        type: array
+                      envFrom:
+                        description: Config maps and secrets imported as environment variables
+                        items:
+                          type: object
+                          x-kubernetes-preserve-unknown-fields: true
+                        type: array
+                      secrets:
+                        description: Secrets mounted into the test container
+                        items:
+                          properties:
+                            name:
+                              type: string
+                            path:
+                              type: string
+                          required:
+                          - name
+                          type: object
+                        type: array
+                      configMaps:
+                        description: Config maps mounted into the test container
+                        items:
+                          properties:
+                            name:
+                              type: string
+                            path:
+                              type: string
+                          required:
+                          - name
+                          type: object
+                        type: array
+                      timeout:
+                        description: Maximum duration of the test run, e.g. 30m
                         type: string
-                      errorMessage:
+                      dependsOn:
+                        description: Names of the tests in the namespace that must pass before this test runs
+                        items:
+                          type: string
+                        type: array
+                      retries:
+                        description: Number of times a failed test is run again
+                        type: integer
+                      retryBackoff:
+                        description: Time to wait before the first retry, doubled with each further retry, e.g. 10s
                         type: string
-                  type: array
-              type: object
-            testID:
-              type: string
-            version:
-              type: string
-          type: object
-  version: v1alpha1
+                      runtime:
+                        properties:
+                          image:
+                            description: Container image used to run the test
+                            type: string
+                          imagePullPolicy:
+                            type: string
+                          imagePullSecrets:
+                            items:
+                              properties:
+                                name:
+                                  type: string
+                              type: object
+                            type: array
+                          maven:
+                            description: Maven settings used to resolve the test runtime dependencies
+                            properties:
+                              settings:
+                                description: Reference to a settings.xml stored in a config map or secret
+                                type: object
+                                x-kubernetes-preserve-unknown-fields: true
+                              mirror:
+                                description: Repository mirroring all remote repositories
+                                type: string
+                              username:
+                                description: Secret key holding the mirror username
+                                properties:
+                                  key:
+                                    type: string
+                                  name:
+                                    type: string
+                                  optional:
+                                    type: boolean
+                                type: object
+                              password:
+                                description: Secret key holding the mirror password
+                                properties:
+                                  key:
+                                    type: string
+                                  name:
+                                    type: string
+                                  optional:
+                                    type: boolean
+                                type: object
+                            type: object
+                          pod:
+                            description: Customizations merged into the pod running the test
+                            type: object
+                            x-kubernetes-preserve-unknown-fields: true
+                        type: object
+                    type: object
+                required:
+                - spec
+                type: object
+              concurrencyPolicy:
+                description: How to treat a scheduled run while the previous run is still active
+                enum:
+                - Allow
+                - Forbid
+                - Replace
+                type: string
+              successfulHistoryLimit:
+                description: The number of passed test runs to keep
+                format: int32
+                minimum: 0
+                type: integer
+              failedHistoryLimit:
+                description: The number of failed test runs to keep
+                format: int32
+                minimum: 0
+                type: integer
+            required:
+            - schedule
+            - template
+            type: object
+          status:
+            properties:
+              lastScheduleTime:
+                format: date-time
+                type: string
+              lastSuccessfulTime:
+                format: date-time
+                type: string
+              lastRun:
+                type: string
+              lastResult:
+                type: string
+              active:
+                items:
+                  type: string
+                type: array
+              errors:
+                items:
+                  properties:
+                    test:
+                      type: string
+                    scenario:
+                      type: string
+                    type:
+                      type: string
+                    message:
+                      type: string
+                    location:
+                      type: string
+                  type: object
+                type: array
+            type: object
+        type: object
+
+`
+	Resources["crds/yaks_v1alpha1_testsuite_cr.yaml"] =
+		`
+apiVersion: org.citrusframework.yaks/v1alpha1
+kind: TestSuite
+metadata:
+  name: example-suite
+spec:
+  policy: Sequential
+  tests:
+  - name: example-test
+  - name: hello
+    spec:
+      source:
+        name: hello.feature
+        language: feature
+        content: |-
+          Feature: hello
+
+            Scenario: print hello
+              Given print 'Hello YAKS'
+
+`
+	Resources["crds/yaks_v1alpha1_testsuite_crd.yaml"] =
+		`
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: testsuites.org.citrusframework.yaks
+spec:
+  group: org.citrusframework.yaks
+  names:
+    kind: TestSuite
+    listKind: TestSuiteList
+    plural: testsuites
+    singular: testsuite
+  scope: Namespaced
   versions:
   - name: v1alpha1
     served: true
     storage: true
+    subresources:
+      status: {}
+    additionalPrinterColumns:
+    - name: Phase
+      type: string
+      description: The test suite phase
+      jsonPath: .status.phase
+    - name: Policy
+      type: string
+      description: The execution policy
+      jsonPath: .spec.policy
+    - name: Total
+      type: integer
+      description: The total amount of tests
+      jsonPath: .status.summary.total
+    - name: Passed
+      type: integer
+      description: Passed tests
+      jsonPath: .status.summary.passed
+    - name: Failed
+      type: integer
+      description: Failed tests
+      jsonPath: .status.summary.failed
+    - name: Skipped
+      type: integer
+      description: Skipped tests
+      jsonPath: .status.summary.skipped
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              tests:
+                description: The tests of the suite, either embedded or referencing an existing test
+                items:
+                  properties:
+                    name:
+                      type: string
+                    ref:
+                      description: Name of an existing test in the namespace, defaults to the entry name
+                      type: string
+                    spec:
+                      description: The embedded test spec
+                      type: object
+                      x-kubernetes-preserve-unknown-fields: true
+                  required:
+                  - name
+                  type: object
+                type: array
+              policy:
+                description: The order in which the tests are executed
+                enum:
+                - Sequential
+                - Parallel
+                - FailFast
+                type: string
+            type: object
+          status:
+            properties:
+              phase:
+                type: string
+              summary:
+                properties:
+                  total:
+                    type: integer
+                  passed:
+                    type: integer
+                  failed:
+                    type: integer
+                  skipped:
+                    type: integer
+                  pending:
+                    type: integer
+                  undefined:
+                    type: integer
+                  flaky:
+                    type: integer
+                type: object
+              tests:
+                items:
+                  properties:
+                    name:
+                      type: string
+                    test:
+                      type: string
+                    phase:
+                      type: string
+                    results:
+                      type: object
+                      x-kubernetes-preserve-unknown-fields: true
+                    errors:
+                      items:
+                        properties:
+                          test:
+                            type: string
+                          scenario:
+                            type: string
+                          type:
+                            type: string
+                          message:
+                            type: string
+                          location:
+                            type: string
+                        type: object
+                      type: array
+                  type: object
+                type: array
+              errors:
+                items:
+                  properties:
+                    test:
+                      type: string
+                    scenario:
+                      type: string
+                    type:
+                      type: string
+                    message:
+                      type: string
+                    location:
+                      type: string
+                  type: object
+                type: array
+            type: object
+        type: object
 
 `
 
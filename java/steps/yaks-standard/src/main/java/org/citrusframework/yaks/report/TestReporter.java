@@ -62,7 +62,7 @@ public class TestReporter extends CitrusReporter {
     }
 
     private void addTestDetail(TestCaseStarted event) {
-        testResults.addTestResult(new TestResult(event.getTestCase().getId(), event.getTestCase().getUri() + ":" + event.getTestCase().getLine()));
+        testResults.addTestResult(new TestResult(event.getTestCase().getId(), event.getTestCase().getUri() + ":" + event.getTestCase().getLine(), event.getTestCase().getName()));
     }
 
     /**
@@ -80,7 +80,8 @@ public class TestReporter extends CitrusReporter {
                 testDetail.get().setCause(event.getResult().getError());
             } else {
                 testResults.addTestResult(new TestResult(event.getTestCase().getId(),
-                                            event.getTestCase().getUri() + ":" + event.getTestCase().getLine(), event.getResult().getError()));
+                                            event.getTestCase().getUri() + ":" + event.getTestCase().getLine(), event.getTestCase().getName(),
+                                            event.getResult().getError()));
             }
         }
     }
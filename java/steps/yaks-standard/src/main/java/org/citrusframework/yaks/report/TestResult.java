@@ -29,16 +29,19 @@ class TestResult {
 
     private final UUID id;
     private final String name;
+    private final String scenario;
     private Throwable cause;
 
-    public TestResult(UUID id, String name) {
+    public TestResult(UUID id, String name, String scenario) {
         this.id = id;
         this.name = name;
+        this.scenario = scenario;
     }
 
-    public TestResult(UUID id, String name, Throwable cause) {
+    public TestResult(UUID id, String name, String scenario, Throwable cause) {
         this.id = id;
         this.name = name;
+        this.scenario = scenario;
         this.cause = cause;
     }
 
@@ -51,6 +54,11 @@ class TestResult {
         return name;
     }
 
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    public String getScenario() {
+        return scenario;
+    }
+
     @JsonInclude(JsonInclude.Include.NON_NULL)
     public String getErrorType() {
         if (cause == null) {
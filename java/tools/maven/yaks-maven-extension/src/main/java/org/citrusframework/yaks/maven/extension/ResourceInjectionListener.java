@@ -17,18 +17,26 @@
 
 package org.citrusframework.yaks.maven.extension;
 
+import java.util.ArrayList;
 import java.util.List;
 
 import org.citrusframework.yaks.maven.extension.configuration.FileBasedDependencyLoader;
 import org.citrusframework.yaks.maven.extension.configuration.cucumber.FeatureTagsDependencyLoader;
 import org.citrusframework.yaks.maven.extension.configuration.env.EnvironmentSettingDependencyLoader;
+import org.citrusframework.yaks.maven.extension.configuration.env.EnvironmentSettingRepositoryLoader;
 import org.citrusframework.yaks.maven.extension.configuration.properties.SystemPropertyDependencyLoader;
+import org.apache.maven.artifact.repository.ArtifactRepository;
+import org.apache.maven.artifact.repository.ArtifactRepositoryPolicy;
+import org.apache.maven.artifact.repository.MavenArtifactRepository;
+import org.apache.maven.artifact.repository.layout.DefaultRepositoryLayout;
 import org.apache.maven.execution.ProjectExecutionEvent;
 import org.apache.maven.execution.ProjectExecutionListener;
 import org.apache.maven.lifecycle.LifecycleExecutionException;
 import org.apache.maven.model.Dependency;
 import org.apache.maven.model.Model;
+import org.apache.maven.model.Repository;
 import org.apache.maven.model.Resource;
+import org.apache.maven.project.MavenProject;
 import org.codehaus.plexus.component.annotations.Component;
 import org.codehaus.plexus.component.annotations.Requirement;
 import org.codehaus.plexus.logging.Logger;
@@ -48,6 +56,7 @@ public class ResourceInjectionListener implements ProjectExecutionListener {
     @Override
     public void beforeProjectExecution(ProjectExecutionEvent projectExecutionEvent) throws LifecycleExecutionException {
         Model projectModel = projectExecutionEvent.getProject().getModel();
+        injectProjectRepositories(projectExecutionEvent.getProject());
         injectProjectDependencies(projectModel);
         injectTestResources(projectModel);
     }
@@ -69,6 +78,27 @@ public class ResourceInjectionListener implements ProjectExecutionListener {
         }
     }
 
+    /**
+     * Dynamically add project repositories based on environment settings. Repositories are added to the project model
+     * as well as to the remote repositories used to resolve the project dependencies.
+     * @param project
+     * @throws LifecycleExecutionException
+     */
+    private void injectProjectRepositories(MavenProject project) throws LifecycleExecutionException {
+        List<Repository> repositoryList = new EnvironmentSettingRepositoryLoader().load(logger);
+        if (repositoryList.isEmpty()) {
+            return;
+        }
+
+        List<ArtifactRepository> remoteRepositories = new ArrayList<>(project.getRemoteArtifactRepositories());
+        for (Repository repository : repositoryList) {
+            project.getModel().addRepository(repository);
+            remoteRepositories.add(new MavenArtifactRepository(repository.getId(), repository.getUrl(),
+                    new DefaultRepositoryLayout(), new ArtifactRepositoryPolicy(), new ArtifactRepositoryPolicy()));
+        }
+        project.setRemoteArtifactRepositories(remoteRepositories);
+    }
+
     /**
      * Dynamically add project dependencies based on different configuration sources such as environment variables,
      * system properties configuration files.
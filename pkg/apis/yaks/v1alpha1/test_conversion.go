@@ -0,0 +1,4 @@
+package v1alpha1
+
+// Hub marks v1alpha1 as the storage version of tests. All other API versions convert from and to this version.
+func (*Test) Hub() {}
@@ -0,0 +1,70 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// TestError describes a failure of a test. Errors reported by the test runtime refer to the failed scenario and its
+// location in the feature file.
+type TestError struct {
+	Test     string `json:"test,omitempty"`
+	Scenario string `json:"scenario,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Message  string `json:"message"`
+	Location string `json:"location,omitempty"`
+}
+
+// String returns a human readable description of the error
+func (e TestError) String() string {
+	var b strings.Builder
+	if e.Test != "" {
+		b.WriteString(e.Test + ": ")
+	}
+	if e.Scenario != "" {
+		b.WriteString("Scenario '" + e.Scenario + "' failed")
+		if e.Type != "" {
+			b.WriteString(" with " + e.Type)
+		}
+		b.WriteString(": ")
+	} else if e.Type != "" {
+		b.WriteString(e.Type + ": ")
+	}
+	b.WriteString(e.Message)
+	if e.Location != "" {
+		b.WriteString(" (" + e.Location + ")")
+	}
+	return b.String()
+}
+
+// UnmarshalJSON also accepts a plain error message as written by previous versions
+func (e *TestError) UnmarshalJSON(data []byte) error {
+	var message string
+	if err := json.Unmarshal(data, &message); err == nil {
+		*e = TestError{Message: message}
+		return nil
+	}
+
+	type plain TestError
+	return json.Unmarshal(data, (*plain)(e))
+}
+
+// TestErrors --
+type TestErrors []TestError
+
+// UnmarshalJSON also accepts the string holding a JSON list of error messages as written by previous versions
+func (errs *TestErrors) UnmarshalJSON(data []byte) error {
+	var legacy string
+	if err := json.Unmarshal(data, &legacy); err == nil {
+		*errs = nil
+		if legacy == "" {
+			return nil
+		}
+		if err := json.Unmarshal([]byte(legacy), (*[]TestError)(errs)); err != nil {
+			*errs = TestErrors{{Message: legacy}}
+		}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]TestError)(errs))
+}
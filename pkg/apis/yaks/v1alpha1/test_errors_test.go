@@ -0,0 +1,33 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalTestErrors(t *testing.T) {
+	status := TestStatus{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"errors": [{"test": "hello.feature", "scenario": "Say hello", "type": "AssertionError", "message": "expected", "location": "hello.feature:3"}]}`), &status))
+	assert.Equal(t, TestErrors{{Test: "hello.feature", Scenario: "Say hello", Type: "AssertionError", Message: "expected", Location: "hello.feature:3"}}, status.Errors)
+
+	status = TestStatus{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"errors": "[\"hello.feature failed with AssertionError: expected\"]"}`), &status))
+	assert.Equal(t, TestErrors{{Message: "hello.feature failed with AssertionError: expected"}}, status.Errors)
+
+	status = TestStatus{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"errors": "invalid test source"}`), &status))
+	assert.Equal(t, TestErrors{{Message: "invalid test source"}}, status.Errors)
+
+	results := TestResults{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"errors": ["group failed", {"message": "test failed"}]}`), &results))
+	assert.Equal(t, TestErrors{{Message: "group failed"}, {Message: "test failed"}}, results.Errors)
+}
+
+func TestErrorString(t *testing.T) {
+	assert.Equal(t, "invalid test source", TestError{Message: "invalid test source"}.String())
+	assert.Equal(t, "hello: Timeout: test timed out after 30m0s", TestError{Test: "hello", Type: "Timeout", Message: "test timed out after 30m0s"}.String())
+	assert.Equal(t, "hello.feature: Scenario 'Say hello' failed with AssertionError: expected (hello.feature:3)",
+		TestError{Test: "hello.feature", Scenario: "Say hello", Type: "AssertionError", Message: "expected", Location: "hello.feature:3"}.String())
+}
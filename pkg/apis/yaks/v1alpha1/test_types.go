@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"fmt"
 
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -16,22 +17,113 @@ type TestSpec struct {
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
 	// Add custom validation using kubebuilder tags: https://book-v1.book.kubebuilder.io/beyond_basics/generating_crd.html
 
-	Source   SourceSpec   `json:"source,omitempty"`
-	Settings SettingsSpec `json:"config,omitempty"`
-	Env      []string     `json:"env,omitempty"`
+	Source       SourceSpec         `json:"source,omitempty"`
+	Sources      []SourceSpec       `json:"sources,omitempty"`
+	Resources    []ResourceSpec     `json:"resources,omitempty"`
+	Settings     SettingsSpec       `json:"config,omitempty"`
+	Dependencies []DependencySpec   `json:"dependencies,omitempty"`
+	Repositories []RepositorySpec   `json:"repositories,omitempty"`
+	Env          []string           `json:"env,omitempty"`
+	EnvVars      []v1.EnvVar        `json:"envVars,omitempty"`
+	EnvFrom      []v1.EnvFromSource `json:"envFrom,omitempty"`
+	Secrets      []MountSpec        `json:"secrets,omitempty"`
+	ConfigMaps   []MountSpec        `json:"configMaps,omitempty"`
+	Runtime      RuntimeSpec        `json:"runtime,omitempty"`
+	Timeout      string             `json:"timeout,omitempty"`
+	DependsOn    []string           `json:"dependsOn,omitempty"`
+	Retries      int                `json:"retries,omitempty"`
+	RetryBackoff string             `json:"retryBackoff,omitempty"`
+}
+
+// DependencySpec is a Maven artifact that gets added to the test runtime
+type DependencySpec struct {
+	GroupID    string `json:"groupId"`
+	ArtifactID string `json:"artifactId"`
+	Version    string `json:"version"`
+}
+
+// String returns the Maven coordinates in the format groupId:artifactId:version
+func (d DependencySpec) String() string {
+	return fmt.Sprintf("%s:%s:%s", d.GroupID, d.ArtifactID, d.Version)
+}
+
+// RepositorySpec is a Maven repository used to resolve the test runtime dependencies
+type RepositorySpec struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// ResourceSpec holds a resource file used by the test. The name is the path of the file relative to the tests path.
+// Large resources can be stored in a config map or secret instead of the inline content. Resources exceeding the
+// config map size limit are split into parts, the file content is the concatenation of all referenced parts in order.
+type ResourceSpec struct {
+	Name      string                    `json:"name,omitempty"`
+	Content   string                    `json:"content,omitempty"`
+	ConfigMap *v1.ConfigMapKeySelector  `json:"configMap,omitempty"`
+	Secret    *v1.SecretKeySelector     `json:"secret,omitempty"`
+	Parts     []v1.ConfigMapKeySelector `json:"parts,omitempty"`
+}
+
+// RuntimeSpec--
+type RuntimeSpec struct {
+	Image            string                    `json:"image,omitempty"`
+	ImagePullPolicy  v1.PullPolicy             `json:"imagePullPolicy,omitempty"`
+	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	Pod              PodSpec                   `json:"pod,omitempty"`
+	Maven            MavenSpec                 `json:"maven,omitempty"`
+}
+
+// MavenSpec configures how the test runtime resolves Maven artifacts. Either a custom settings.xml or a mirror
+// repository may be given. The mirror credentials are read from secrets.
+type MavenSpec struct {
+	Settings *MavenSettingsSpec    `json:"settings,omitempty"`
+	Mirror   string                `json:"mirror,omitempty"`
+	Username *v1.SecretKeySelector `json:"username,omitempty"`
+	Password *v1.SecretKeySelector `json:"password,omitempty"`
+}
+
+// IsEmpty tells whether no Maven settings are configured
+func (m MavenSpec) IsEmpty() bool {
+	return m.Settings == nil && m.Mirror == "" && m.Username == nil && m.Password == nil
+}
+
+// MavenSettingsSpec references a Maven settings.xml file stored in a config map or secret
+type MavenSettingsSpec struct {
+	ConfigMap *v1.ConfigMapKeySelector `json:"configMap,omitempty"`
+	Secret    *v1.SecretKeySelector    `json:"secret,omitempty"`
+}
+
+// MountSpec references a secret or config map that gets mounted into the test container at the given path
+type MountSpec struct {
+	Name string `json:"name"`
+	Path string `json:"path,omitempty"`
+}
+
+// PodSpec holds customizations that get merged into the pod running the test
+type PodSpec struct {
+	Resources          v1.ResourceRequirements `json:"resources,omitempty"`
+	NodeSelector       map[string]string       `json:"nodeSelector,omitempty"`
+	Tolerations        []v1.Toleration         `json:"tolerations,omitempty"`
+	Affinity           *v1.Affinity            `json:"affinity,omitempty"`
+	SecurityContext    *v1.PodSecurityContext  `json:"securityContext,omitempty"`
+	ServiceAccountName string                  `json:"serviceAccountName,omitempty"`
+	Volumes            []v1.Volume             `json:"volumes,omitempty"`
+	VolumeMounts       []v1.VolumeMount        `json:"volumeMounts,omitempty"`
 }
 
 // SourceSpec--
 type SourceSpec struct {
-	Name     string   `json:"name,omitempty"`
-	Content  string   `json:"content,omitempty"`
-	Language Language `json:"language,omitempty"`
+	Name     string                    `json:"name,omitempty"`
+	Content  string                    `json:"content,omitempty"`
+	Language Language                  `json:"language,omitempty"`
+	Parts    []v1.ConfigMapKeySelector `json:"parts,omitempty"`
 }
 
 // SettingsSpec--
 type SettingsSpec struct {
-	Name    string `json:"name,omitempty"`
-	Content string `json:"content,omitempty"`
+	Name    string                    `json:"name,omitempty"`
+	Content string                    `json:"content,omitempty"`
+	Parts   []v1.ConfigMapKeySelector `json:"parts,omitempty"`
 }
 
 // TestStatus defines the observed state of Test
@@ -43,10 +135,40 @@ type TestStatus struct {
 
 	Phase   TestPhase   `json:"phase,omitempty"`
 	Results TestResults `json:"results,omitempty"`
-	Errors	string      `json:"errors,omitempty"`
+	Errors  TestErrors  `json:"errors,omitempty"`
 	TestID  string      `json:"testID,omitempty"`
 	Digest  string      `json:"digest,omitempty"`
 	Version string      `json:"version,omitempty"`
+
+	Conditions []TestCondition `json:"conditions,omitempty"`
+	Attempts   []TestAttempt   `json:"attempts,omitempty"`
+	RetryTime  *metav1.Time    `json:"retryTime,omitempty"`
+}
+
+// TestAttempt records the outcome of a single run of a test that gets retried on failure
+type TestAttempt struct {
+	TestID     string      `json:"testID,omitempty"`
+	Phase      TestPhase   `json:"phase,omitempty"`
+	Summary    TestSummary `json:"summary,omitempty"`
+	Errors     TestErrors  `json:"errors,omitempty"`
+	FinishedAt metav1.Time `json:"finishedAt,omitempty"`
+}
+
+// TestConditionType --
+type TestConditionType string
+
+const (
+	// TestConditionWaitingForDependencies is true while the test waits for the tests it depends on to pass
+	TestConditionWaitingForDependencies TestConditionType = "WaitingForDependencies"
+)
+
+// TestCondition describes the state of a test at a certain point
+type TestCondition struct {
+	Type               TestConditionType  `json:"type"`
+	Status             v1.ConditionStatus `json:"status"`
+	LastTransitionTime metav1.Time        `json:"lastTransitionTime,omitempty"`
+	Reason             string             `json:"reason,omitempty"`
+	Message            string             `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -74,7 +196,8 @@ type TestList struct {
 type TestResults struct {
 	Summary TestSummary  `json:"summary,omitempty"`
 	Tests	[]TestResult `json:"tests,omitempty"`
-	Errors 	[]string 	 `json:"errors,omitempty"`
+	Errors 	TestErrors 	 `json:"errors,omitempty"`
+	Duration string      `json:"duration,omitempty"`
 }
 
 type TestSummary struct {
@@ -84,12 +207,16 @@ type TestSummary struct {
 	Skipped 	int   	  `json:"skipped"`
 	Pending 	int   	  `json:"pending"`
 	Undefined 	int   	  `json:"undefined"`
+	Flaky 		int   	  `json:"flaky,omitempty"`
 }
 
 type TestResult struct {
 	Name         string  `json:"name,omitempty"`
+	Scenario     string  `json:"scenario,omitempty"`
 	ErrorType    string  `json:"errorType,omitempty"`
 	ErrorMessage string  `json:"errorMessage,omitempty"`
+	Flaky        bool    `json:"flaky,omitempty"`
+	Skipped      bool    `json:"skipped,omitempty"`
 }
 
 // TestPhase --
@@ -111,6 +238,8 @@ const (
 	TestPhaseFailed TestPhase = "Failed"
 	// TestPhaseError --
 	TestPhaseError TestPhase = "Error"
+	// TestPhaseSkipped --
+	TestPhaseSkipped TestPhase = "Skipped"
 	// TestPhaseDeleting --
 	TestPhaseDeleting TestPhase = "Deleting"
 )
@@ -122,6 +251,36 @@ func (phase TestPhase) AsError() error {
 	return nil
 }
 
+// GetCondition returns the condition of the given type or nil if the test has no such condition
+func (status *TestStatus) GetCondition(conditionType TestConditionType) *TestCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == conditionType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or updates the condition of the given type. The transition time only changes with the status.
+func (status *TestStatus) SetCondition(conditionType TestConditionType, conditionStatus v1.ConditionStatus, reason string, message string) {
+	condition := TestCondition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+
+	if existing := status.GetCondition(conditionType); existing != nil {
+		if existing.Status == conditionStatus {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		*existing = condition
+		return
+	}
+	status.Conditions = append(status.Conditions, condition)
+}
+
 type Language string
 
 const (
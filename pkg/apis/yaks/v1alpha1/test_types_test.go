@@ -0,0 +1,32 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetCondition(t *testing.T) {
+	status := TestStatus{}
+	assert.Nil(t, status.GetCondition(TestConditionWaitingForDependencies))
+
+	status.SetCondition(TestConditionWaitingForDependencies, v1.ConditionTrue, "DependenciesNotPassed", "waiting for a")
+	condition := status.GetCondition(TestConditionWaitingForDependencies)
+	assert.Equal(t, v1.ConditionTrue, condition.Status)
+	assert.Equal(t, "waiting for a", condition.Message)
+
+	transition := metav1.NewTime(condition.LastTransitionTime.Add(-time.Minute))
+	condition.LastTransitionTime = transition
+	status.SetCondition(TestConditionWaitingForDependencies, v1.ConditionTrue, "DependenciesNotPassed", "waiting for b")
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, "waiting for b", status.Conditions[0].Message)
+	assert.Equal(t, transition, status.Conditions[0].LastTransitionTime)
+
+	status.SetCondition(TestConditionWaitingForDependencies, v1.ConditionFalse, "DependenciesPassed", "")
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, v1.ConditionFalse, status.Conditions[0].Status)
+	assert.NotEqual(t, transition, status.Conditions[0].LastTransitionTime)
+}
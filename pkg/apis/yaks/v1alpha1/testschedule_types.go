@@ -0,0 +1,90 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestScheduleSpec defines when and how the scheduled tests are run
+// +k8s:openapi-gen=true
+type TestScheduleSpec struct {
+	// The schedule in cron format, e.g. "*/15 * * * *" or "@hourly", evaluated in UTC
+	Schedule          string                `json:"schedule"`
+	Template          TestTemplateSpec      `json:"template"`
+	ConcurrencyPolicy TestConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// The number of passed test runs to keep, defaults to 3
+	SuccessfulHistoryLimit *int32 `json:"successfulHistoryLimit,omitempty"`
+	// The number of failed test runs to keep, defaults to 1
+	FailedHistoryLimit *int32 `json:"failedHistoryLimit,omitempty"`
+}
+
+// TestTemplateSpec describes the tests created for each scheduled run
+type TestTemplateSpec struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	Spec   TestSpec          `json:"spec"`
+}
+
+// TestConcurrencyPolicy defines how to treat a scheduled run while the previous run is still active
+type TestConcurrencyPolicy string
+
+const (
+	// TestConcurrencyAllow runs tests concurrently
+	TestConcurrencyAllow TestConcurrencyPolicy = "Allow"
+	// TestConcurrencyForbid skips the new run if the previous run is still active
+	TestConcurrencyForbid TestConcurrencyPolicy = "Forbid"
+	// TestConcurrencyReplace removes the active run and replaces it with the new run
+	TestConcurrencyReplace TestConcurrencyPolicy = "Replace"
+)
+
+// TestConcurrencyPolicies is the list of all supported concurrency policies
+var TestConcurrencyPolicies = []TestConcurrencyPolicy{
+	TestConcurrencyAllow,
+	TestConcurrencyForbid,
+	TestConcurrencyReplace,
+}
+
+// TestScheduleStatus defines the observed state of TestSchedule
+// +k8s:openapi-gen=true
+type TestScheduleStatus struct {
+	LastScheduleTime   *metav1.Time `json:"lastScheduleTime,omitempty"`
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	LastRun            string       `json:"lastRun,omitempty"`
+	LastResult         TestPhase    `json:"lastResult,omitempty"`
+	Active             []string     `json:"active,omitempty"`
+	Errors             TestErrors   `json:"errors,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TestSchedule is the Schema for the testschedules API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type TestSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TestScheduleSpec   `json:"spec,omitempty"`
+	Status TestScheduleStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TestScheduleList contains a list of TestSchedule
+type TestScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TestSchedule `json:"items"`
+}
+
+const (
+	// TestScheduleKind --
+	TestScheduleKind string = "TestSchedule"
+
+	// TestScheduleLabel marks the tests created for a schedule
+	TestScheduleLabel = "org.citrusframework.yaks/schedule"
+	// TestScheduledTimeAnnotation holds the time a scheduled test run was due
+	TestScheduledTimeAnnotation = "org.citrusframework.yaks/scheduled-time"
+)
+
+func init() {
+	SchemeBuilder.Register(&TestSchedule{}, &TestScheduleList{})
+}
@@ -0,0 +1,92 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestSuiteSpec defines the tests of a suite and how they are executed
+// +k8s:openapi-gen=true
+type TestSuiteSpec struct {
+	Tests  []TestSuiteEntry `json:"tests,omitempty"`
+	Policy TestSuitePolicy  `json:"policy,omitempty"`
+}
+
+// TestSuiteEntry is a test of the suite. The test is either embedded with its spec or references an existing test in
+// the suite namespace by name, the reference defaults to the entry name. In both cases the suite runs its own copy
+// of the test.
+type TestSuiteEntry struct {
+	Name string    `json:"name"`
+	Ref  string    `json:"ref,omitempty"`
+	Spec *TestSpec `json:"spec,omitempty"`
+}
+
+// TestSuitePolicy defines the order in which the tests of a suite are executed
+type TestSuitePolicy string
+
+const (
+	// TestSuitePolicySequential runs one test after another
+	TestSuitePolicySequential TestSuitePolicy = "Sequential"
+	// TestSuitePolicyParallel runs all tests at the same time
+	TestSuitePolicyParallel TestSuitePolicy = "Parallel"
+	// TestSuitePolicyFailFast runs one test after another and skips the remaining tests after the first failure
+	TestSuitePolicyFailFast TestSuitePolicy = "FailFast"
+)
+
+// TestSuitePolicies is the list of all supported execution policies
+var TestSuitePolicies = []TestSuitePolicy{
+	TestSuitePolicySequential,
+	TestSuitePolicyParallel,
+	TestSuitePolicyFailFast,
+}
+
+// TestSuiteStatus aggregates the state of all tests in the suite
+// +k8s:openapi-gen=true
+type TestSuiteStatus struct {
+	Phase   TestPhase         `json:"phase,omitempty"`
+	Summary TestSummary       `json:"summary,omitempty"`
+	Tests   []TestSuiteResult `json:"tests,omitempty"`
+	Errors  TestErrors        `json:"errors,omitempty"`
+}
+
+// TestSuiteResult is the state of a single test in the suite
+type TestSuiteResult struct {
+	Name    string      `json:"name"`
+	Test    string      `json:"test,omitempty"`
+	Phase   TestPhase   `json:"phase,omitempty"`
+	Results TestResults `json:"results,omitempty"`
+	Errors  TestErrors  `json:"errors,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TestSuite is the Schema for the testsuites API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type TestSuite struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TestSuiteSpec   `json:"spec,omitempty"`
+	Status TestSuiteStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TestSuiteList contains a list of TestSuite
+type TestSuiteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TestSuite `json:"items"`
+}
+
+const (
+	// TestSuiteKind --
+	TestSuiteKind string = "TestSuite"
+
+	// TestSuiteLabel marks the tests created for a suite
+	TestSuiteLabel = "org.citrusframework.yaks/suite"
+)
+
+func init() {
+	SchemeBuilder.Register(&TestSuite{}, &TestSuiteList{})
+}
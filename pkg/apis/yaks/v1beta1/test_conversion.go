@@ -0,0 +1,154 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this test to the v1alpha1 storage version
+func (t *Test) ConvertTo(hub conversion.Hub) error {
+	out := hub.(*v1alpha1.Test)
+	out.ObjectMeta = *t.ObjectMeta.DeepCopy()
+
+	if err := convertSpec(t.Spec, &out.Spec); err != nil {
+		return err
+	}
+	for _, env := range t.Spec.Env {
+		if env.ValueFrom == nil {
+			out.Spec.Env = append(out.Spec.Env, env.Name+"="+env.Value)
+		} else {
+			out.Spec.EnvVars = append(out.Spec.EnvVars, *env.DeepCopy())
+		}
+	}
+	out.Spec.Settings = v1alpha1.SettingsSpec{
+		Name:    t.Spec.Settings.Name,
+		Content: t.Spec.Settings.Content,
+	}
+	for _, part := range t.Spec.Settings.Parts {
+		out.Spec.Settings.Parts = append(out.Spec.Settings.Parts, *part.DeepCopy())
+	}
+	if t.Spec.Timeout != nil {
+		out.Spec.Timeout = t.Spec.Timeout.Duration.String()
+	}
+	out.Spec.Retries = t.Spec.Retries
+	if t.Spec.RetryBackoff != nil {
+		out.Spec.RetryBackoff = t.Spec.RetryBackoff.Duration.String()
+	}
+
+	if err := convertJSON(t.Status.Results, &out.Status.Results); err != nil {
+		return err
+	}
+	out.Status.Phase = v1alpha1.TestPhase(t.Status.Phase)
+	out.Status.TestID = t.Status.TestID
+	out.Status.Digest = t.Status.Digest
+	out.Status.Version = t.Status.Version
+	if err := convertJSON(t.Status.Errors, &out.Status.Errors); err != nil {
+		return err
+	}
+	if err := convertJSON(t.Status.Conditions, &out.Status.Conditions); err != nil {
+		return err
+	}
+	if err := convertJSON(t.Status.Attempts, &out.Status.Attempts); err != nil {
+		return err
+	}
+	out.Status.RetryTime = t.Status.RetryTime.DeepCopy()
+
+	return nil
+}
+
+// ConvertFrom converts a test in the v1alpha1 storage version to this version
+func (t *Test) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1alpha1.Test)
+	t.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	if err := convertSpec(src.Spec, &t.Spec); err != nil {
+		return err
+	}
+	for _, env := range src.Spec.Env {
+		// entries not using the KEY=VALUE form never made it into the test container so they get dropped
+		if pair := strings.SplitN(env, "=", 2); len(pair) == 2 {
+			t.Spec.Env = append(t.Spec.Env, v1.EnvVar{Name: pair[0], Value: pair[1]})
+		}
+	}
+	for _, env := range src.Spec.EnvVars {
+		t.Spec.Env = append(t.Spec.Env, *env.DeepCopy())
+	}
+	t.Spec.Settings = SettingsSpec{
+		Name:    src.Spec.Settings.Name,
+		Content: src.Spec.Settings.Content,
+	}
+	for _, part := range src.Spec.Settings.Parts {
+		t.Spec.Settings.Parts = append(t.Spec.Settings.Parts, *part.DeepCopy())
+	}
+	if src.Spec.Timeout != "" {
+		timeout, err := time.ParseDuration(src.Spec.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %q of test %s: %v", src.Spec.Timeout, src.Name, err)
+		}
+		t.Spec.Timeout = &metav1.Duration{Duration: timeout}
+	}
+	t.Spec.Retries = src.Spec.Retries
+	if src.Spec.RetryBackoff != "" {
+		backoff, err := time.ParseDuration(src.Spec.RetryBackoff)
+		if err != nil {
+			return fmt.Errorf("invalid retry backoff %q of test %s: %v", src.Spec.RetryBackoff, src.Name, err)
+		}
+		t.Spec.RetryBackoff = &metav1.Duration{Duration: backoff}
+	}
+
+	if err := convertJSON(src.Status.Results, &t.Status.Results); err != nil {
+		return err
+	}
+	t.Status.Phase = TestPhase(src.Status.Phase)
+	t.Status.TestID = src.Status.TestID
+	t.Status.Digest = src.Status.Digest
+	t.Status.Version = src.Status.Version
+	if err := convertJSON(src.Status.Errors, &t.Status.Errors); err != nil {
+		return err
+	}
+	if err := convertJSON(src.Status.Conditions, &t.Status.Conditions); err != nil {
+		return err
+	}
+	if err := convertJSON(src.Status.Attempts, &t.Status.Attempts); err != nil {
+		return err
+	}
+	t.Status.RetryTime = src.Status.RetryTime.DeepCopy()
+
+	return nil
+}
+
+// convertSpec copies the spec fields that have the same structure in both versions. These are converted through
+// their common JSON representation.
+func convertSpec(in interface{}, out interface{}) error {
+	var common struct {
+		Source       json.RawMessage `json:"source,omitempty"`
+		Sources      json.RawMessage `json:"sources,omitempty"`
+		Resources    json.RawMessage `json:"resources,omitempty"`
+		Dependencies json.RawMessage `json:"dependencies,omitempty"`
+		Repositories json.RawMessage `json:"repositories,omitempty"`
+		EnvFrom      json.RawMessage `json:"envFrom,omitempty"`
+		Secrets      json.RawMessage `json:"secrets,omitempty"`
+		ConfigMaps   json.RawMessage `json:"configMaps,omitempty"`
+		Runtime      json.RawMessage `json:"runtime,omitempty"`
+		DependsOn    json.RawMessage `json:"dependsOn,omitempty"`
+	}
+	if err := convertJSON(in, &common); err != nil {
+		return err
+	}
+	return convertJSON(common, out)
+}
+
+func convertJSON(in interface{}, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
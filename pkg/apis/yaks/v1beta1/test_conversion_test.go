@@ -0,0 +1,109 @@
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConvertFromV1alpha1(t *testing.T) {
+	secret := &v1.EnvVarSource{
+		SecretKeyRef: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "credentials"},
+			Key:                  "password",
+		},
+	}
+	src := v1alpha1.Test{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"},
+		Spec: v1alpha1.TestSpec{
+			Source:   v1alpha1.SourceSpec{Name: "hello.feature", Content: "Feature: Hello", Language: v1alpha1.LanguageGherkin},
+			Settings: v1alpha1.SettingsSpec{Name: "yaks.settings.yaml", Content: "dependencies: []"},
+			Env:      []string{"GREETING=Hello", "broken"},
+			EnvVars:  []v1.EnvVar{{Name: "PASSWORD", ValueFrom: secret}},
+			Dependencies: []v1alpha1.DependencySpec{
+				{GroupID: "org.foo", ArtifactID: "foo", Version: "1.0"},
+			},
+			Runtime:   v1alpha1.RuntimeSpec{Image: "yaks:latest"},
+			Timeout:   "10m",
+			DependsOn: []string{"provisioning"},
+			Retries:   2,
+		},
+		Status: v1alpha1.TestStatus{
+			Phase:   v1alpha1.TestPhaseFailed,
+			Results: v1alpha1.TestResults{Duration: "2m5s"},
+			Errors:  v1alpha1.TestErrors{{Test: "hello.feature", Type: "AssertionError", Message: "expected"}},
+			TestID:  "42",
+			Conditions: []v1alpha1.TestCondition{
+				{Type: v1alpha1.TestConditionWaitingForDependencies, Status: v1.ConditionFalse, Reason: "DependenciesPassed"},
+			},
+		},
+	}
+
+	dst := Test{}
+	assert.Nil(t, dst.ConvertFrom(&src))
+
+	assert.Equal(t, "hello", dst.Name)
+	assert.Equal(t, "hello.feature", dst.Spec.Source.Name)
+	assert.Equal(t, LanguageGherkin, dst.Spec.Source.Language)
+	assert.Equal(t, "yaks.settings.yaml", dst.Spec.Settings.Name)
+	assert.Equal(t, []v1.EnvVar{{Name: "GREETING", Value: "Hello"}, {Name: "PASSWORD", ValueFrom: secret}}, dst.Spec.Env)
+	assert.Equal(t, []DependencySpec{{GroupID: "org.foo", ArtifactID: "foo", Version: "1.0"}}, dst.Spec.Dependencies)
+	assert.Equal(t, "yaks:latest", dst.Spec.Runtime.Image)
+	assert.Equal(t, 10*time.Minute, dst.Spec.Timeout.Duration)
+	assert.Equal(t, TestPhaseFailed, dst.Status.Phase)
+	assert.Equal(t, []TestError{{Test: "hello.feature", Type: "AssertionError", Message: "expected"}}, dst.Status.Errors)
+	assert.Equal(t, "42", dst.Status.TestID)
+	assert.Equal(t, []string{"provisioning"}, dst.Spec.DependsOn)
+	assert.Equal(t, 2, dst.Spec.Retries)
+	assert.Equal(t, 125*time.Second, dst.Status.Results.Duration.Duration)
+	assert.Equal(t, TestConditionWaitingForDependencies, dst.Status.Conditions[0].Type)
+	assert.Equal(t, "DependenciesPassed", dst.Status.Conditions[0].Reason)
+
+	src.Spec.Timeout = "soon"
+	assert.NotNil(t, dst.ConvertFrom(&src))
+}
+
+func TestConvertToV1alpha1(t *testing.T) {
+	endpoint := &v1.EnvVarSource{
+		ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "endpoints"},
+			Key:                  "url",
+		},
+	}
+	src := Test{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"},
+		Spec: TestSpec{
+			Source:   SourceSpec{Name: "hello.feature", Content: "Feature: Hello", Language: LanguageGherkin},
+			Settings: SettingsSpec{Name: "yaks.settings.yaml", Content: "dependencies: []"},
+			Env:      []v1.EnvVar{{Name: "GREETING", Value: "Hello"}, {Name: "URL", ValueFrom: endpoint}},
+			Secrets:  []MountSpec{{Name: "credentials"}},
+			Timeout:  &metav1.Duration{Duration: 90 * time.Second},
+		},
+		Status: TestStatus{
+			Phase:  TestPhaseError,
+			Errors: []TestError{{Message: "first"}, {Test: "hello.feature", Message: "second"}},
+		},
+	}
+
+	dst := v1alpha1.Test{}
+	assert.Nil(t, src.ConvertTo(&dst))
+
+	assert.Equal(t, "hello", dst.Name)
+	assert.Equal(t, "Feature: Hello", dst.Spec.Source.Content)
+	assert.Equal(t, "dependencies: []", dst.Spec.Settings.Content)
+	assert.Equal(t, []string{"GREETING=Hello"}, dst.Spec.Env)
+	assert.Equal(t, []v1.EnvVar{{Name: "URL", ValueFrom: endpoint}}, dst.Spec.EnvVars)
+	assert.Equal(t, []v1alpha1.MountSpec{{Name: "credentials"}}, dst.Spec.Secrets)
+	assert.Equal(t, "1m30s", dst.Spec.Timeout)
+	assert.Equal(t, v1alpha1.TestPhaseError, dst.Status.Phase)
+	assert.Equal(t, v1alpha1.TestErrors{{Message: "first"}, {Test: "hello.feature", Message: "second"}}, dst.Status.Errors)
+
+	back := Test{}
+	assert.Nil(t, back.ConvertFrom(&dst))
+	assert.Equal(t, src.Spec, back.Spec)
+	assert.Equal(t, src.Status, back.Status)
+}
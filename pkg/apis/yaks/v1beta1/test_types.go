@@ -0,0 +1,253 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestSpec defines the desired state of Test
+// +k8s:openapi-gen=true
+type TestSpec struct {
+	Source       SourceSpec         `json:"source,omitempty"`
+	Sources      []SourceSpec       `json:"sources,omitempty"`
+	Resources    []ResourceSpec     `json:"resources,omitempty"`
+	Settings     SettingsSpec       `json:"settings,omitempty"`
+	Dependencies []DependencySpec   `json:"dependencies,omitempty"`
+	Repositories []RepositorySpec   `json:"repositories,omitempty"`
+	Env          []v1.EnvVar        `json:"env,omitempty"`
+	EnvFrom      []v1.EnvFromSource `json:"envFrom,omitempty"`
+	Secrets      []MountSpec        `json:"secrets,omitempty"`
+	ConfigMaps   []MountSpec        `json:"configMaps,omitempty"`
+	Runtime      RuntimeSpec        `json:"runtime,omitempty"`
+	Timeout      *metav1.Duration   `json:"timeout,omitempty"`
+	DependsOn    []string           `json:"dependsOn,omitempty"`
+	Retries      int                `json:"retries,omitempty"`
+	RetryBackoff *metav1.Duration   `json:"retryBackoff,omitempty"`
+}
+
+// DependencySpec is a Maven artifact that gets added to the test runtime
+type DependencySpec struct {
+	GroupID    string `json:"groupId"`
+	ArtifactID string `json:"artifactId"`
+	Version    string `json:"version"`
+}
+
+// String returns the Maven coordinates in the format groupId:artifactId:version
+func (d DependencySpec) String() string {
+	return fmt.Sprintf("%s:%s:%s", d.GroupID, d.ArtifactID, d.Version)
+}
+
+// RepositorySpec is a Maven repository used to resolve the test runtime dependencies
+type RepositorySpec struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// ResourceSpec holds a resource file used by the test. The name is the path of the file relative to the tests path.
+// Large resources can be stored in a config map or secret instead of the inline content. Resources exceeding the
+// config map size limit are split into parts, the file content is the concatenation of all referenced parts in order.
+type ResourceSpec struct {
+	Name      string                    `json:"name,omitempty"`
+	Content   string                    `json:"content,omitempty"`
+	ConfigMap *v1.ConfigMapKeySelector  `json:"configMap,omitempty"`
+	Secret    *v1.SecretKeySelector     `json:"secret,omitempty"`
+	Parts     []v1.ConfigMapKeySelector `json:"parts,omitempty"`
+}
+
+// RuntimeSpec --
+type RuntimeSpec struct {
+	Image            string                    `json:"image,omitempty"`
+	ImagePullPolicy  v1.PullPolicy             `json:"imagePullPolicy,omitempty"`
+	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	Pod              PodSpec                   `json:"pod,omitempty"`
+	Maven            MavenSpec                 `json:"maven,omitempty"`
+}
+
+// MavenSpec configures how the test runtime resolves Maven artifacts. Either a custom settings.xml or a mirror
+// repository may be given. The mirror credentials are read from secrets.
+type MavenSpec struct {
+	Settings *MavenSettingsSpec    `json:"settings,omitempty"`
+	Mirror   string                `json:"mirror,omitempty"`
+	Username *v1.SecretKeySelector `json:"username,omitempty"`
+	Password *v1.SecretKeySelector `json:"password,omitempty"`
+}
+
+// MavenSettingsSpec references a Maven settings.xml file stored in a config map or secret
+type MavenSettingsSpec struct {
+	ConfigMap *v1.ConfigMapKeySelector `json:"configMap,omitempty"`
+	Secret    *v1.SecretKeySelector    `json:"secret,omitempty"`
+}
+
+// MountSpec references a secret or config map that gets mounted into the test container at the given path
+type MountSpec struct {
+	Name string `json:"name"`
+	Path string `json:"path,omitempty"`
+}
+
+// PodSpec holds customizations that get merged into the pod running the test
+type PodSpec struct {
+	Resources          v1.ResourceRequirements `json:"resources,omitempty"`
+	NodeSelector       map[string]string       `json:"nodeSelector,omitempty"`
+	Tolerations        []v1.Toleration         `json:"tolerations,omitempty"`
+	Affinity           *v1.Affinity            `json:"affinity,omitempty"`
+	SecurityContext    *v1.PodSecurityContext  `json:"securityContext,omitempty"`
+	ServiceAccountName string                  `json:"serviceAccountName,omitempty"`
+	Volumes            []v1.Volume             `json:"volumes,omitempty"`
+	VolumeMounts       []v1.VolumeMount        `json:"volumeMounts,omitempty"`
+}
+
+// SourceSpec --
+type SourceSpec struct {
+	Name     string                    `json:"name,omitempty"`
+	Content  string                    `json:"content,omitempty"`
+	Language Language                  `json:"language,omitempty"`
+	Parts    []v1.ConfigMapKeySelector `json:"parts,omitempty"`
+}
+
+// SettingsSpec --
+type SettingsSpec struct {
+	Name    string                    `json:"name,omitempty"`
+	Content string                    `json:"content,omitempty"`
+	Parts   []v1.ConfigMapKeySelector `json:"parts,omitempty"`
+}
+
+// TestStatus defines the observed state of Test
+// +k8s:openapi-gen=true
+type TestStatus struct {
+	Phase   TestPhase   `json:"phase,omitempty"`
+	Results TestResults `json:"results,omitempty"`
+	Errors  []TestError `json:"errors,omitempty"`
+	TestID  string      `json:"testID,omitempty"`
+	Digest  string      `json:"digest,omitempty"`
+	Version string      `json:"version,omitempty"`
+
+	Conditions []TestCondition `json:"conditions,omitempty"`
+	Attempts   []TestAttempt   `json:"attempts,omitempty"`
+	RetryTime  *metav1.Time    `json:"retryTime,omitempty"`
+}
+
+// TestAttempt records the outcome of a single run of a test that gets retried on failure
+type TestAttempt struct {
+	TestID     string      `json:"testID,omitempty"`
+	Phase      TestPhase   `json:"phase,omitempty"`
+	Summary    TestSummary `json:"summary,omitempty"`
+	Errors     []TestError `json:"errors,omitempty"`
+	FinishedAt metav1.Time `json:"finishedAt,omitempty"`
+}
+
+// TestConditionType --
+type TestConditionType string
+
+const (
+	// TestConditionWaitingForDependencies is true while the test waits for the tests it depends on to pass
+	TestConditionWaitingForDependencies TestConditionType = "WaitingForDependencies"
+)
+
+// TestCondition describes the state of a test at a certain point
+type TestCondition struct {
+	Type               TestConditionType  `json:"type"`
+	Status             v1.ConditionStatus `json:"status"`
+	LastTransitionTime metav1.Time        `json:"lastTransitionTime,omitempty"`
+	Reason             string             `json:"reason,omitempty"`
+	Message            string             `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Test is the Schema for the tests API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type Test struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TestSpec   `json:"spec,omitempty"`
+	Status TestStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TestList contains a list of Test
+type TestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Test `json:"items"`
+}
+
+// TestResults --
+type TestResults struct {
+	Summary  TestSummary      `json:"summary,omitempty"`
+	Tests    []TestResult     `json:"tests,omitempty"`
+	Errors   []TestError      `json:"errors,omitempty"`
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// TestSummary --
+type TestSummary struct {
+	Total     int `json:"total"`
+	Passed    int `json:"passed"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+	Pending   int `json:"pending"`
+	Undefined int `json:"undefined"`
+	Flaky     int `json:"flaky,omitempty"`
+}
+
+// TestResult --
+type TestResult struct {
+	Name         string `json:"name,omitempty"`
+	Scenario     string `json:"scenario,omitempty"`
+	ErrorType    string `json:"errorType,omitempty"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	Flaky        bool   `json:"flaky,omitempty"`
+	Skipped      bool   `json:"skipped,omitempty"`
+}
+
+// TestError describes a failure of a test. Errors reported by the test runtime refer to the failed scenario and its
+// location in the feature file.
+type TestError struct {
+	Test     string `json:"test,omitempty"`
+	Scenario string `json:"scenario,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Message  string `json:"message"`
+	Location string `json:"location,omitempty"`
+}
+
+// TestPhase --
+type TestPhase string
+
+const (
+	// TestKind --
+	TestKind string = "Test"
+
+	// TestPhaseNone --
+	TestPhaseNone TestPhase = ""
+	// TestPhasePending --
+	TestPhasePending TestPhase = "Pending"
+	// TestPhaseRunning --
+	TestPhaseRunning TestPhase = "Running"
+	// TestPhasePassed --
+	TestPhasePassed TestPhase = "Passed"
+	// TestPhaseFailed --
+	TestPhaseFailed TestPhase = "Failed"
+	// TestPhaseError --
+	TestPhaseError TestPhase = "Error"
+	// TestPhaseSkipped --
+	TestPhaseSkipped TestPhase = "Skipped"
+	// TestPhaseDeleting --
+	TestPhaseDeleting TestPhase = "Deleting"
+)
+
+// Language --
+type Language string
+
+const (
+	// LanguageGherkin --
+	LanguageGherkin Language = "feature"
+)
+
+func init() {
+	SchemeBuilder.Register(&Test{}, &TestList{})
+}
@@ -0,0 +1,191 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/client"
+	"github.com/citrusframework/yaks/pkg/cmd/config"
+	"github.com/citrusframework/yaks/pkg/controller/testsuite"
+	"github.com/citrusframework/yaks/pkg/install"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// tempNamespacePattern matches the names of temporary namespaces created by the YAKS CLI
+var tempNamespacePattern = regexp.MustCompile(`^yaks-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+func newCmdCleanup(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	options := cleanupCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		PersistentPreRunE: options.preRun,
+		Use:               "cleanup [options]",
+		Short:             "Delete expired or orphaned temporary test namespaces",
+		Long:              `Deletes temporary test namespaces that have exceeded their time to live as well as orphaned namespaces left behind by crashed or interrupted test runs. Webhook configurations of operators whose namespace is gone are removed, too.`,
+		PreRunE:           options.validateArgs,
+		RunE:              options.run,
+		SilenceUsage:      true,
+	}
+
+	cmd.Flags().BoolVar(&options.all, "all", false, "Delete all temporary test namespaces that are not in use regardless of their time to live")
+	cmd.Flags().BoolVar(&options.force, "force", false, "Together with --all also delete temporary test namespaces that are in use by running tests")
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", false, "Only print the namespaces that would be deleted")
+
+	return &cmd
+}
+
+type cleanupCmdOptions struct {
+	*RootCmdOptions
+	all    bool
+	force  bool
+	dryRun bool
+}
+
+func (o *cleanupCmdOptions) validateArgs(_ *cobra.Command, _ []string) error {
+	if o.force && !o.all {
+		return errors.New("option --force requires --all")
+	}
+	return nil
+}
+
+func (o *cleanupCmdOptions) run(cmd *cobra.Command, _ []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := listNamespaces(o.Context, c)
+	if err != nil {
+		return err
+	}
+
+	deleted := 0
+	failed := 0
+	now := time.Now()
+	for i := range namespaces {
+		namespace := &namespaces[i]
+		if garbage, err := o.isGarbage(c, namespace, now); err != nil {
+			return err
+		} else if !garbage {
+			continue
+		}
+
+		if o.dryRun {
+			if _, err := fmt.Fprintf(cmd.OutOrStdout(), "Would delete namespace %s\n", namespace.Name); err != nil {
+				return err
+			}
+		} else if err := deleteTempNamespace(newNamespace(namespace.Name), c, o.Context); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "WARN: %v\n", err)
+			failed++
+			continue
+		}
+		deleted++
+	}
+
+	// webhook configurations are cluster wide, so they outlive namespaces removed without the CLI
+	orphaned, err := install.OrphanedWebhooks(o.Context, c)
+	if err != nil {
+		return err
+	}
+	for _, namespace := range orphaned {
+		if o.dryRun {
+			if _, err := fmt.Fprintf(cmd.OutOrStdout(), "Would delete webhooks of namespace %s\n", namespace); err != nil {
+				return err
+			}
+		} else if err := install.DeleteWebhooks(o.Context, c, namespace); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "WARN: %v\n", err)
+			failed++
+		}
+	}
+
+	if _, err = fmt.Fprintf(cmd.OutOrStdout(), "Cleanup finished: %d namespaces removed\n", deleted); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errors.New(fmt.Sprintf("cleanup failed for %d namespaces", failed))
+	}
+	return nil
+}
+
+// isGarbage returns true if the given namespace is a temporary test namespace that can be removed. Namespaces within
+// their time to live are only removed with --all as long as no test run uses them, unless the removal is forced.
+func (o *cleanupCmdOptions) isGarbage(c client.Client, namespace *corev1.Namespace, now time.Time) (bool, error) {
+	if namespace.Labels[TemporaryLabel] != "true" {
+		// orphaned namespace created by a CLI version that did not label its temporary namespaces, the namespace may
+		// still be in use so it expires after the default time to live
+		if !tempNamespacePattern.MatchString(namespace.Name) {
+			return false, nil
+		}
+		if namespace.CreationTimestamp.Add(config.DefaultNamespaceTTL).Before(now) {
+			return true, nil
+		}
+	} else if namespace.Labels[PoolLabel] == "true" && namespace.Labels[PoolStateLabel] == PoolStateReady {
+		// ready namespaces are not in use, drain the pool in order to remove them
+		return false, nil
+	} else if isExpired(namespace, now) {
+		return true, nil
+	}
+
+	if !o.all {
+		return false, nil
+	}
+	if o.force {
+		return true, nil
+	}
+
+	inUse, err := isInUse(o.Context, c, namespace)
+	return !inUse, err
+}
+
+// isInUse returns true if the given temporary namespace is claimed from the pool or still runs tests
+func isInUse(ctx context.Context, c client.Client, namespace *corev1.Namespace) (bool, error) {
+	if namespace.Labels[PoolLabel] == "true" && namespace.Labels[PoolStateLabel] != PoolStateReady {
+		return true, nil
+	}
+
+	tests := v1alpha1.TestList{}
+	if err := c.List(ctx, &tests, k8sclient.InNamespace(namespace.Name)); err != nil {
+		return false, err
+	}
+	for _, test := range tests.Items {
+		if !testsuite.IsFinished(test.Status.Phase) {
+			return true, nil
+		}
+	}
+
+	suites := v1alpha1.TestSuiteList{}
+	if err := c.List(ctx, &suites, k8sclient.InNamespace(namespace.Name)); err != nil {
+		return false, err
+	}
+	for _, suite := range suites.Items {
+		if !testsuite.IsFinished(suite.Status.Phase) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/install"
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newWebhookObjects(namespace string) []runtime.Object {
+	meta := metav1.ObjectMeta{
+		Name: install.WebhookServiceName + "-" + namespace,
+		Labels: map[string]string{
+			"app":                         "yaks",
+			install.WebhookNamespaceLabel: namespace,
+		},
+	}
+	return []runtime.Object{
+		&admissionv1beta1.MutatingWebhookConfiguration{ObjectMeta: meta},
+		&admissionv1beta1.ValidatingWebhookConfiguration{ObjectMeta: meta},
+	}
+}
+
+func TestOrphanedWebhooks(t *testing.T) {
+	objects := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "yaks"}, Status: corev1.NamespaceStatus{Phase: corev1.NamespaceActive}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "yaks-terminating"}, Status: corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating}},
+	}
+	objects = append(objects, newWebhookObjects("yaks")...)
+	objects = append(objects, newWebhookObjects("yaks-terminating")...)
+	objects = append(objects, newWebhookObjects("yaks-gone")...)
+	c := newFakeClient(objects...)
+
+	orphaned, err := install.OrphanedWebhooks(context.TODO(), c)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"yaks-terminating", "yaks-gone"}, orphaned)
+}
+
+func TestDeleteTempNamespaceWebhooks(t *testing.T) {
+	objects := append(newWebhookObjects("yaks"), newWebhookObjects("yaks-gone")...)
+	objects = append(objects, newNamespace("yaks-gone"))
+	c := newFakeClient(objects...)
+
+	assert.Nil(t, deleteTempNamespace(newNamespace("yaks-gone"), c, context.TODO()))
+
+	mutating := admissionv1beta1.MutatingWebhookConfigurationList{}
+	assert.Nil(t, c.List(context.TODO(), &mutating))
+	assert.Len(t, mutating.Items, 1)
+	assert.Equal(t, "yaks-webhook-yaks", mutating.Items[0].Name)
+
+	validating := admissionv1beta1.ValidatingWebhookConfiguration{}
+	err := c.Get(context.TODO(), k8sclient.ObjectKey{Name: "yaks-webhook-yaks-gone"}, &validating)
+	assert.NotNil(t, err)
+}
+
+func newTempNamespace(name string, createdAt time.Time) *corev1.Namespace {
+	namespace := newNamespace(name)
+	namespace.Labels = map[string]string{
+		TemporaryLabel: "true",
+		CreatedAtLabel: strconv.FormatInt(createdAt.Unix(), 10),
+		TTLLabel:       "1h",
+	}
+	return namespace
+}
+
+func TestIsGarbage(t *testing.T) {
+	now := time.Now()
+	expired := newTempNamespace("yaks-expired", now.Add(-2*time.Hour))
+	idle := newTempNamespace("yaks-idle", now)
+	running := newTempNamespace("yaks-running", now)
+	claimed := newTempNamespace("yaks-claimed", now)
+	claimed.Labels[PoolLabel] = "true"
+	claimed.Labels[PoolStateLabel] = PoolStateInUse
+	ready := newTempNamespace("yaks-ready", now.Add(-2*time.Hour))
+	ready.Labels[PoolLabel] = "true"
+	ready.Labels[PoolStateLabel] = PoolStateReady
+
+	test := v1alpha1.Test{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "yaks-running", Name: "hello"},
+		Status:     v1alpha1.TestStatus{Phase: v1alpha1.TestPhaseRunning},
+	}
+	c := newFakeClient(&test)
+
+	expectations := []struct {
+		options   cleanupCmdOptions
+		namespace *corev1.Namespace
+		garbage   bool
+	}{
+		{cleanupCmdOptions{}, expired, true},
+		{cleanupCmdOptions{}, idle, false},
+		{cleanupCmdOptions{}, ready, false},
+		{cleanupCmdOptions{all: true}, idle, true},
+		{cleanupCmdOptions{all: true}, running, false},
+		{cleanupCmdOptions{all: true}, claimed, false},
+		{cleanupCmdOptions{all: true}, ready, false},
+		{cleanupCmdOptions{all: true, force: true}, running, true},
+		{cleanupCmdOptions{all: true, force: true}, claimed, true},
+	}
+
+	for _, expectation := range expectations {
+		options := expectation.options
+		options.RootCmdOptions = &RootCmdOptions{Context: context.TODO()}
+
+		garbage, err := options.isGarbage(c, expectation.namespace, now)
+		assert.Nil(t, err)
+		assert.Equal(t, expectation.garbage, garbage, expectation.namespace.Name)
+	}
+}
@@ -18,20 +18,28 @@ limitations under the License.
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"time"
 
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/util/maven"
 	"gopkg.in/yaml.v2"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 )
 
 type RunConfig struct {
-	Config Config       `yaml:"config"`
-	Pre    []StepConfig `yaml:"pre"`
-	Post   []StepConfig `yaml:"post"`
+	Config  Config       `yaml:"config"`
+	Pre     []StepConfig `yaml:"pre"`
+	Post    []StepConfig `yaml:"post"`
+	BaseDir string       `yaml:"-"`
 }
 
 type Config struct {
 	Recursive bool `yaml:"recursive"`
+	FailFast  bool `yaml:"failFast"`
 	Namespace NamespaceConfig
 	Runtime   RuntimeConfig
 }
@@ -44,7 +52,99 @@ type StepConfig struct {
 }
 
 type RuntimeConfig struct {
-	Cucumber CucumberConfig
+	Cucumber         CucumberConfig
+	Image            string    `yaml:"image"`
+	ImagePullPolicy  string    `yaml:"imagePullPolicy"`
+	ImagePullSecrets []string  `yaml:"imagePullSecrets"`
+	Pod              PodConfig `yaml:"pod"`
+	Resources        []string  `yaml:"resources"`
+
+	Dependencies []DependencyConfig `yaml:"dependencies"`
+	Repositories []RepositoryConfig `yaml:"repositories"`
+	Maven        MavenConfig        `yaml:"maven"`
+	Env          []EnvConfig        `yaml:"env"`
+	EnvFrom      []EnvFromConfig    `yaml:"envFrom"`
+	Secrets      []string           `yaml:"secrets"`
+	ConfigMaps   []string           `yaml:"configMaps"`
+}
+
+// EnvConfig is an environment variable of the test runtime. The value is either given as plain value or read from
+// a secret or config map key in the format name/key.
+type EnvConfig struct {
+	Name      string `yaml:"name"`
+	Value     string `yaml:"value"`
+	Secret    string `yaml:"secret"`
+	ConfigMap string `yaml:"configMap"`
+}
+
+// EnvFromConfig imports all keys of a secret or config map as environment variables of the test runtime
+type EnvFromConfig struct {
+	Secret    string `yaml:"secret"`
+	ConfigMap string `yaml:"configMap"`
+	Prefix    string `yaml:"prefix"`
+}
+
+// MavenConfig holds the Maven settings of the test runtime. Settings reference a settings.xml in the format
+// configmap:name[/key] or secret:name[/key]. The mirror secret holds the mirror credentials as keys username and password.
+type MavenConfig struct {
+	Settings     string `yaml:"settings"`
+	Mirror       string `yaml:"mirror"`
+	MirrorSecret string `yaml:"mirrorSecret"`
+}
+
+// DependencyConfig is a Maven dependency given either as groupId:artifactId:version coordinates or as
+// mapping with groupId, artifactId and version keys
+type DependencyConfig struct {
+	GroupID    string `yaml:"groupId"`
+	ArtifactID string `yaml:"artifactId"`
+	Version    string `yaml:"version"`
+}
+
+// UnmarshalYAML supports Maven coordinates as well as the mapping format
+func (c *DependencyConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var coordinates string
+	if err := unmarshal(&coordinates); err == nil {
+		dependency, err := maven.ParseDependency(coordinates)
+		if err != nil {
+			return err
+		}
+		*c = DependencyConfig(dependency)
+		return nil
+	}
+
+	type plain DependencyConfig
+	return unmarshal((*plain)(c))
+}
+
+type RepositoryConfig struct {
+	ID  string `yaml:"id"`
+	URL string `yaml:"url"`
+}
+
+// PodConfig holds customizations for the pod running the test. The settings use the same format
+// as the runtime pod section of the Test custom resource.
+type PodConfig struct {
+	v1alpha1.PodSpec
+}
+
+// UnmarshalYAML reads the pod settings with the json field names used by the Kubernetes API types
+func (c *PodConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw interface{}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(raw)
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := k8syaml.ToJSON(data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(jsonData, &c.PodSpec)
 }
 
 type CucumberConfig struct {
@@ -54,14 +154,115 @@ type CucumberConfig struct {
 }
 
 type NamespaceConfig struct {
-	Name       string `yaml:"name"`
-	Temporary  bool   `yaml:"temporary"`
-	AutoRemove bool   `yaml:"autoremove"`
+	Name       string           `yaml:"name"`
+	Temporary  bool             `yaml:"temporary"`
+	AutoRemove AutoRemovePolicy `yaml:"autoremove"`
+	TTL        string           `yaml:"ttl"`
+	Pool       PoolConfig       `yaml:"pool"`
+
+	Labels      map[string]string `yaml:"labels"`
+	Annotations map[string]string `yaml:"annotations"`
+	Quota       map[string]string `yaml:"quota"`
+	Limits      LimitsConfig      `yaml:"limits"`
+}
+
+// LimitsConfig holds the container limits applied to all containers in the namespace
+type LimitsConfig struct {
+	Default        map[string]string `yaml:"default"`
+	DefaultRequest map[string]string `yaml:"defaultRequest"`
+	Max            map[string]string `yaml:"max"`
+	Min            map[string]string `yaml:"min"`
+}
+
+// IsEmpty returns true if no limit has been set
+func (l LimitsConfig) IsEmpty() bool {
+	return len(l.Default) == 0 && len(l.DefaultRequest) == 0 && len(l.Max) == 0 && len(l.Min) == 0
+}
+
+// AutoRemovePolicy defines when a temporary namespace is removed after the test run
+type AutoRemovePolicy string
+
+const (
+	AutoRemoveAlways    AutoRemovePolicy = "always"
+	AutoRemoveNever     AutoRemovePolicy = "never"
+	AutoRemoveOnSuccess AutoRemovePolicy = "onSuccess"
+
+	DefaultNamespaceTTL = 24 * time.Hour
+)
+
+type PoolConfig struct {
+	Size int `yaml:"size"`
+}
+
+// UnmarshalYAML supports the camel case "autoRemove" key in addition to "autoremove"
+func (c *NamespaceConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain NamespaceConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+
+	var camelCase struct {
+		AutoRemove *AutoRemovePolicy `yaml:"autoRemove"`
+	}
+	if err := unmarshal(&camelCase); err != nil {
+		return err
+	}
+	if camelCase.AutoRemove != nil {
+		c.AutoRemove = *camelCase.AutoRemove
+	}
+
+	return nil
+}
+
+// GetTTL returns the time to live of temporary namespaces
+func (c NamespaceConfig) GetTTL() time.Duration {
+	if ttl, err := time.ParseDuration(c.TTL); err == nil && ttl > 0 {
+		return ttl
+	}
+	return DefaultNamespaceTTL
+}
+
+// UnmarshalYAML accepts boolean values as well as the policy names
+func (p *AutoRemovePolicy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var enabled bool
+	if err := unmarshal(&enabled); err == nil {
+		if enabled {
+			*p = AutoRemoveAlways
+		} else {
+			*p = AutoRemoveNever
+		}
+		return nil
+	}
+
+	var policy string
+	if err := unmarshal(&policy); err != nil {
+		return err
+	}
+
+	switch AutoRemovePolicy(policy) {
+	case AutoRemoveAlways, AutoRemoveNever, AutoRemoveOnSuccess:
+		*p = AutoRemovePolicy(policy)
+	default:
+		return fmt.Errorf("unsupported namespace autoRemove policy '%s', please use one of true, false, '%s'", policy, AutoRemoveOnSuccess)
+	}
+	return nil
+}
+
+// Remove returns true if the temporary namespace should be removed after a test run with given outcome
+func (p AutoRemovePolicy) Remove(success bool) bool {
+	switch p {
+	case AutoRemoveNever:
+		return false
+	case AutoRemoveOnSuccess:
+		return success
+	default:
+		return true
+	}
 }
 
 func NewWithDefaults() *RunConfig {
 	ns := NamespaceConfig{
-		AutoRemove: true,
+		AutoRemove: AutoRemoveAlways,
 		Temporary:  false,
 	}
 
@@ -0,0 +1,174 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestNamespaceAutoRemove(t *testing.T) {
+	config := NewWithDefaults()
+	assert.True(t, config.Config.Namespace.AutoRemove.Remove(false))
+
+	assert.Nil(t, yaml.Unmarshal([]byte(`
+config:
+  namespace:
+    temporary: true
+    autoremove: false
+`), config))
+	assert.Equal(t, AutoRemoveNever, config.Config.Namespace.AutoRemove)
+	assert.False(t, config.Config.Namespace.AutoRemove.Remove(true))
+
+	config = NewWithDefaults()
+	assert.Nil(t, yaml.Unmarshal([]byte(`
+config:
+  namespace:
+    temporary: true
+    autoRemove: onSuccess
+    ttl: 2h
+`), config))
+	assert.Equal(t, AutoRemoveOnSuccess, config.Config.Namespace.AutoRemove)
+	assert.True(t, config.Config.Namespace.AutoRemove.Remove(true))
+	assert.False(t, config.Config.Namespace.AutoRemove.Remove(false))
+	assert.Equal(t, 2*time.Hour, config.Config.Namespace.GetTTL())
+
+	assert.NotNil(t, yaml.Unmarshal([]byte(`
+config:
+  namespace:
+    autoRemove: sometimes
+`), NewWithDefaults()))
+}
+
+func TestNamespaceLimits(t *testing.T) {
+	config := NewWithDefaults()
+	assert.True(t, config.Config.Namespace.Limits.IsEmpty())
+
+	assert.Nil(t, yaml.Unmarshal([]byte(`
+config:
+  namespace:
+    temporary: true
+    labels:
+      team: integration
+    quota:
+      requests.cpu: 2
+      limits.memory: 8Gi
+    limits:
+      defaultRequest:
+        memory: 256Mi
+`), config))
+	assert.Equal(t, "integration", config.Config.Namespace.Labels["team"])
+	assert.Equal(t, "2", config.Config.Namespace.Quota["requests.cpu"])
+	assert.Equal(t, "8Gi", config.Config.Namespace.Quota["limits.memory"])
+	assert.False(t, config.Config.Namespace.Limits.IsEmpty())
+	assert.Equal(t, "256Mi", config.Config.Namespace.Limits.DefaultRequest["memory"])
+}
+
+func TestRuntimePod(t *testing.T) {
+	config := NewWithDefaults()
+	assert.Nil(t, yaml.Unmarshal([]byte(`
+config:
+  runtime:
+    pod:
+      serviceAccountName: tester
+      nodeSelector:
+        disktype: ssd
+      resources:
+        limits:
+          memory: 1Gi
+      tolerations:
+      - key: dedicated
+        operator: Equal
+        value: test
+        effect: NoSchedule
+      volumeMounts:
+      - name: data
+        mountPath: /data
+`), config))
+
+	pod := config.Config.Runtime.Pod
+	assert.Equal(t, "tester", pod.ServiceAccountName)
+	assert.Equal(t, "ssd", pod.NodeSelector["disktype"])
+	assert.Equal(t, "1Gi", pod.Resources.Limits.Memory().String())
+	assert.Equal(t, "dedicated", pod.Tolerations[0].Key)
+	assert.Equal(t, "/data", pod.VolumeMounts[0].MountPath)
+}
+
+func TestRuntimeDependencies(t *testing.T) {
+	config := NewWithDefaults()
+	assert.Nil(t, yaml.Unmarshal([]byte(`
+config:
+  runtime:
+    dependencies:
+    - org.foo:foo-artifact:1.0.0
+    - groupId: org.bar
+      artifactId: bar-artifact
+      version: 1.5.0
+    repositories:
+    - id: jboss-ea
+      url: https://repository.jboss.org/nexus/content/groups/ea/
+`), config))
+
+	dependencies := config.Config.Runtime.Dependencies
+	assert.Equal(t, []DependencyConfig{
+		{GroupID: "org.foo", ArtifactID: "foo-artifact", Version: "1.0.0"},
+		{GroupID: "org.bar", ArtifactID: "bar-artifact", Version: "1.5.0"},
+	}, dependencies)
+	assert.Equal(t, "jboss-ea", config.Config.Runtime.Repositories[0].ID)
+
+	assert.NotNil(t, yaml.Unmarshal([]byte(`
+config:
+  runtime:
+    dependencies:
+    - org.foo:foo-artifact
+`), NewWithDefaults()))
+}
+
+func TestRuntimeImage(t *testing.T) {
+	config := NewWithDefaults()
+	assert.Nil(t, yaml.Unmarshal([]byte(`
+config:
+  runtime:
+    image: quay.io/example/yaks-runtime:1.0
+    imagePullPolicy: Always
+    imagePullSecrets:
+    - quay-pull-secret
+`), config))
+
+	runtime := config.Config.Runtime
+	assert.Equal(t, "quay.io/example/yaks-runtime:1.0", runtime.Image)
+	assert.Equal(t, "Always", runtime.ImagePullPolicy)
+	assert.Equal(t, []string{"quay-pull-secret"}, runtime.ImagePullSecrets)
+}
+
+func TestRuntimeResources(t *testing.T) {
+	config := NewWithDefaults()
+	assert.Nil(t, yaml.Unmarshal([]byte(`
+config:
+  runtime:
+    resources:
+    - data
+    - payloads/**/*.json
+`), config))
+
+	assert.Equal(t, []string{"data", "payloads/**/*.json"}, config.Config.Runtime.Resources)
+	assert.Equal(t, "", config.BaseDir)
+}
@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/cmd/config"
+	"github.com/citrusframework/yaks/pkg/util/maven"
+	"github.com/pkg/errors"
+)
+
+// setupDependencies adds the runtime dependencies and repositories from the run configuration and the command line
+// to the test. Dependencies are checked against the ones in the settings file so that malformed coordinates and
+// version conflicts are reported before the test is submitted.
+func (o *testCmdOptions) setupDependencies(test *v1alpha1.Test, runConfig *config.RunConfig) error {
+	known := make([]v1alpha1.DependencySpec, 0)
+	if test.Spec.Settings.Name != "" {
+		var err error
+		if known, err = maven.LoadSettingsDependencies(test.Spec.Settings.Name, test.Spec.Settings.Content); err != nil {
+			return err
+		}
+	}
+
+	dependencies := make([]v1alpha1.DependencySpec, 0, len(runConfig.Config.Runtime.Dependencies)+len(o.dependencies))
+	for _, dependency := range runConfig.Config.Runtime.Dependencies {
+		dependencies = append(dependencies, v1alpha1.DependencySpec(dependency))
+	}
+
+	for _, coordinates := range o.dependencies {
+		dependency, err := maven.ParseDependency(coordinates)
+		if err != nil {
+			return err
+		}
+		dependencies = append(dependencies, dependency)
+	}
+
+	merged, err := maven.MergeDependencies(known, dependencies...)
+	if err != nil {
+		return errors.Wrap(err, "invalid runtime dependencies")
+	}
+
+	if len(merged) > len(known) {
+		// dependencies from the settings file are loaded by the runtime already
+		test.Spec.Dependencies = merged[len(known):]
+	}
+
+	repositories := make([]v1alpha1.RepositorySpec, 0, len(runConfig.Config.Runtime.Repositories)+len(o.repositories))
+	for _, repository := range runConfig.Config.Runtime.Repositories {
+		repositories = append(repositories, v1alpha1.RepositorySpec(repository))
+	}
+
+	for _, value := range o.repositories {
+		repository, err := maven.ParseRepository(value)
+		if err != nil {
+			return err
+		}
+		repositories = append(repositories, repository)
+	}
+
+	urls := make(map[string]string)
+	for _, repository := range repositories {
+		if err := maven.ValidateRepository(repository); err != nil {
+			return err
+		}
+
+		if url, ok := urls[repository.ID]; ok {
+			if url != repository.URL {
+				return errors.New(fmt.Sprintf("conflicting urls %s and %s for repository %s", url, repository.URL, repository.ID))
+			}
+			continue
+		}
+
+		urls[repository.ID] = repository.URL
+		test.Spec.Repositories = append(test.Spec.Repositories, repository)
+	}
+
+	return nil
+}
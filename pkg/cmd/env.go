@@ -0,0 +1,168 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/cmd/config"
+	"github.com/citrusframework/yaks/pkg/util/envvar"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// setupEnvSources adds environment variables read from secret and config map keys as well as whole secrets and config maps
+// to the test. Values given on the command line override the ones from the run configuration.
+func (o *testCmdOptions) setupEnvSources(test *v1alpha1.Test, runConfig *config.RunConfig) error {
+	vars := make([]corev1.EnvVar, 0)
+
+	for _, env := range runConfig.Config.Runtime.Env {
+		if env.Name == "" {
+			return errors.New("missing name of environment variable in runtime config")
+		}
+
+		switch {
+		case env.Secret != "" && env.ConfigMap != "":
+			return errors.New(fmt.Sprintf("environment variable '%s' must not reference both a secret and a config map", env.Name))
+		case env.Secret != "":
+			name, key, err := parseKeyReference(env.Secret)
+			if err != nil {
+				return err
+			}
+			envvar.SetValFromSecret(&vars, env.Name, name, key)
+		case env.ConfigMap != "":
+			name, key, err := parseKeyReference(env.ConfigMap)
+			if err != nil {
+				return err
+			}
+			envvar.SetValFromConfigMap(&vars, env.Name, name, key)
+		default:
+			envvar.SetVal(&vars, env.Name, env.Value)
+		}
+	}
+
+	sources := make([]corev1.EnvFromSource, 0)
+	for _, env := range runConfig.Config.Runtime.EnvFrom {
+		switch {
+		case env.Secret != "" && env.ConfigMap != "":
+			return errors.New("environment source must not reference both a secret and a config map")
+		case env.Secret != "":
+			sources = append(sources, secretEnvSource(env.Secret, env.Prefix))
+		case env.ConfigMap != "":
+			sources = append(sources, configMapEnvSource(env.ConfigMap, env.Prefix))
+		default:
+			return errors.New("environment source must reference a secret or a config map")
+		}
+	}
+
+	for _, value := range o.envFromSecrets {
+		if name, ref, ok := splitEnvReference(value); ok {
+			secret, key, err := parseKeyReference(ref)
+			if err != nil {
+				return err
+			}
+			envvar.SetValFromSecret(&vars, name, secret, key)
+		} else {
+			sources = append(sources, secretEnvSource(value, ""))
+		}
+	}
+
+	for _, value := range o.envFromConfigMaps {
+		if name, ref, ok := splitEnvReference(value); ok {
+			configMap, key, err := parseKeyReference(ref)
+			if err != nil {
+				return err
+			}
+			envvar.SetValFromConfigMap(&vars, name, configMap, key)
+		} else {
+			sources = append(sources, configMapEnvSource(value, ""))
+		}
+	}
+
+	if len(vars) > 0 {
+		test.Spec.EnvVars = vars
+	}
+	if len(sources) > 0 {
+		test.Spec.EnvFrom = sources
+	}
+
+	return nil
+}
+
+// setEnvValue sets the variable in the given list of KEY=VALUE pairs. An existing entry of the variable gets replaced.
+func setEnvValue(env *[]string, name string, value string) {
+	for i, entry := range *env {
+		if pair := strings.SplitN(entry, "=", 2); strings.TrimSpace(pair[0]) == name {
+			(*env)[i] = name + "=" + value
+			return
+		}
+	}
+	*env = append(*env, name+"="+value)
+}
+
+// hasEnvValue tells whether the given list of KEY=VALUE pairs sets the variable
+func hasEnvValue(env []string, name string) bool {
+	for _, entry := range env {
+		if pair := strings.SplitN(entry, "=", 2); strings.TrimSpace(pair[0]) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// splitEnvReference splits values in the format NAME=reference
+func splitEnvReference(value string) (string, string, bool) {
+	pair := strings.SplitN(value, "=", 2)
+	if len(pair) != 2 {
+		return "", "", false
+	}
+	return strings.TrimSpace(pair[0]), strings.TrimSpace(pair[1]), true
+}
+
+// parseKeyReference reads a secret or config map key reference in the format name/key
+func parseKeyReference(ref string) (string, string, error) {
+	pair := strings.SplitN(ref, "/", 2)
+	if len(pair) != 2 || pair[0] == "" || pair[1] == "" {
+		return "", "", errors.New(fmt.Sprintf("malformed key reference '%s', expected name/key", ref))
+	}
+	return pair[0], pair[1], nil
+}
+
+func secretEnvSource(name string, prefix string) corev1.EnvFromSource {
+	return corev1.EnvFromSource{
+		Prefix: prefix,
+		SecretRef: &corev1.SecretEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: name,
+			},
+		},
+	}
+}
+
+func configMapEnvSource(name string, prefix string) corev1.EnvFromSource {
+	return corev1.EnvFromSource{
+		Prefix: prefix,
+		ConfigMapRef: &corev1.ConfigMapEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: name,
+			},
+		},
+	}
+}
@@ -20,11 +20,15 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
 	"github.com/citrusframework/yaks/pkg/client"
 	"github.com/citrusframework/yaks/pkg/install"
+	"github.com/citrusframework/yaks/pkg/util/maven"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func newCmdInstall(rootCmdOptions *RootCmdOptions) *cobra.Command {
@@ -42,19 +46,42 @@ func newCmdInstall(rootCmdOptions *RootCmdOptions) *cobra.Command {
 	cmd.Flags().BoolVar(&impl.clusterSetupOnly, "cluster-setup", false, "Execute cluster-wide operations only (may require admin rights)")
 	cmd.Flags().BoolVar(&impl.skipOperatorSetup, "skip-operator-setup", false, "Do not install the operator in the namespace (in case there's a global one)")
 	cmd.Flags().BoolVar(&impl.skipClusterSetup, "skip-cluster-setup", false, "Skip the cluster-setup phase")
+	cmd.Flags().BoolVar(&impl.global, "global", false, "Install a global operator that watches all namespaces (requires admin rights)")
+	cmd.Flags().BoolVar(&impl.webhook, "webhook", false, "Install the admission webhooks validating and defaulting tests (requires admin rights)")
+	cmd.Flags().BoolVar(&impl.conversionTakeover, "conversion-takeover", false, "Serve the conversion between test API versions from this namespace even if an operator in another namespace serves it")
+	cmd.Flags().StringVar(&impl.maven.Settings, "maven-settings", "", "Maven settings.xml used by all tests, in the format configmap:name[/key] or secret:name[/key]")
+	cmd.Flags().StringVar(&impl.maven.Mirror, "maven-mirror", "", "Maven repository mirroring all remote repositories for all tests")
+	cmd.Flags().StringVar(&impl.maven.MirrorSecret, "maven-mirror-secret", "", "Name of a secret holding the Maven mirror credentials as keys username and password")
+	cmd.Flags().StringVar(&impl.maven.CacheSize, "maven-cache-size", "", "Size of the Maven repository cache shared by the tests of a namespace, e.g. 5Gi (the cache is disabled by default)")
+	cmd.Flags().StringVar(&impl.maven.CacheStorageClass, "maven-cache-storage-class", "", "Storage class of the Maven repository cache")
+	cmd.Flags().StringVar(&impl.maven.CacheAccessMode, "maven-cache-access-mode", "", "Access mode of the Maven repository cache (default ReadWriteMany)")
+	cmd.Flags().IntVar(&impl.namespacePoolSize, "namespace-pool-size", 0, "Number of pre-warmed temporary namespaces used by test runs in the operator namespace (the pool is disabled by default)")
 
 	return &cmd
 }
 
 type installCmdOptions struct {
 	*RootCmdOptions
-	clusterSetupOnly  bool
-	skipOperatorSetup bool
-	skipClusterSetup  bool
+	clusterSetupOnly   bool
+	skipOperatorSetup  bool
+	skipClusterSetup   bool
+	global             bool
+	webhook            bool
+	conversionTakeover bool
+	maven              install.MavenConfiguration
+	namespacePoolSize  int
 }
 
 // nolint: gocyclo
 func (o *installCmdOptions) install(_ *cobra.Command, _ []string) error {
+	if err := o.validateMaven(); err != nil {
+		return err
+	}
+
+	if o.namespacePoolSize < 0 {
+		return errors.New(fmt.Sprintf("invalid namespace pool size %d", o.namespacePoolSize))
+	}
+
 	if !o.skipClusterSetup {
 		if err := setupCluster(o.RootCmdOptions); err != nil {
 			return err
@@ -73,10 +100,63 @@ func (o *installCmdOptions) install(_ *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	err := setupOperator(o.RootCmdOptions, o.Namespace)
+	if o.global {
+		return setupGlobalOperator(o.RootCmdOptions, install.OperatorConfiguration{
+			Namespace:          o.Namespace,
+			Global:             true,
+			Webhook:            o.webhook,
+			Conversion:         true,
+			ConversionTakeover: o.conversionTakeover,
+			Maven:              o.maven,
+			NamespacePoolSize:  o.namespacePoolSize,
+		})
+	}
+
+	err := setupOperator(o.RootCmdOptions, install.OperatorConfiguration{
+		Namespace:          o.Namespace,
+		Webhook:            o.webhook,
+		Conversion:         true,
+		ConversionTakeover: o.conversionTakeover,
+		Maven:              o.maven,
+		NamespacePoolSize:  o.namespacePoolSize,
+	})
 	return err
 }
 
+// validateMaven checks the operator wide Maven settings before the operator gets installed
+func (o *installCmdOptions) validateMaven() error {
+	spec := v1alpha1.MavenSpec{
+		Mirror: o.maven.Mirror,
+	}
+
+	if o.maven.Settings != "" {
+		settings, err := maven.ParseSettingsReference(o.maven.Settings)
+		if err != nil {
+			return err
+		}
+		spec.Settings = settings
+	}
+
+	if err := maven.ValidateSettings(spec); err != nil {
+		return err
+	}
+
+	if o.maven.CacheSize != "" {
+		if _, err := resource.ParseQuantity(o.maven.CacheSize); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("invalid Maven cache size '%s'", o.maven.CacheSize))
+		}
+	}
+
+	switch corev1.PersistentVolumeAccessMode(o.maven.CacheAccessMode) {
+	case "", corev1.ReadWriteMany, corev1.ReadWriteOnce:
+	default:
+		return errors.New(fmt.Sprintf("unsupported Maven cache access mode '%s', please use one of %s, %s",
+			o.maven.CacheAccessMode, corev1.ReadWriteMany, corev1.ReadWriteOnce))
+	}
+
+	return nil
+}
+
 func setupCluster(o *RootCmdOptions) error {
 	// Let's use a client provider during cluster installation, to eliminate the problem of CRD object caching
 	clientProvider := client.Provider{Get: o.NewCmdClient}
@@ -92,20 +172,78 @@ func setupCluster(o *RootCmdOptions) error {
 	return err
 }
 
-func setupOperator(o *RootCmdOptions, namespace string) error {
+func setupOperator(o *RootCmdOptions, cfg install.OperatorConfiguration) error {
 	c, err := o.GetCmdClient()
 	if err != nil {
 		return err
 	}
 
-	cfg := install.OperatorConfiguration{
-		Namespace: namespace,
+	global, err := install.IsGlobalOperatorInstalled(o.Context, c)
+	if err != nil {
+		return err
 	}
+	if global {
+		fmt.Printf("YAKS global operator detected, skipping operator installation in namespace %s\n", cfg.Namespace)
+		return nil
+	}
+
+	if err := setupConversion(o, c, &cfg); err != nil {
+		return err
+	}
+
 	err = install.OperatorOrCollect(o.Context, c, cfg, nil)
-	if err != nil {
+	if err != nil && cfg.Webhook && k8serrors.IsForbidden(errors.Cause(err)) {
+		fmt.Println("Current user is not authorized to create webhook configurations: ", err)
+		return errors.New(`please login as cluster-admin in order to install the operator with admission webhooks`)
+	} else if err != nil {
 		return err
 	}
 
 	fmt.Println("YAKS setup completed successfully")
 	return nil
 }
+
+func setupGlobalOperator(o *RootCmdOptions, cfg install.OperatorConfiguration) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	if err := setupConversion(o, c, &cfg); err != nil {
+		return err
+	}
+
+	err = install.OperatorOrCollect(o.Context, c, cfg, nil)
+	if err != nil && k8serrors.IsForbidden(errors.Cause(err)) {
+		fmt.Println("Current user is not authorized to create cluster roles and bindings: ", err)
+		return errors.New(`please login as cluster-admin in order to install the global operator`)
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Println("YAKS global operator setup completed successfully")
+	return nil
+}
+
+// setupConversion lets the operator serve the conversion between the test API versions unless an operator in another
+// namespace already does so. The conversion stays disabled in the configuration when the operator does not serve it.
+func setupConversion(o *RootCmdOptions, c client.Client, cfg *install.OperatorConfiguration) error {
+	if !cfg.Conversion {
+		return nil
+	}
+
+	owner, err := install.Conversion(o.Context, c, cfg.Namespace, cfg.ConversionTakeover)
+	if err != nil {
+		return err
+	}
+
+	switch owner {
+	case cfg.Namespace:
+	case "":
+		fmt.Println("Current user is not authorized to configure the test conversion, only API version v1alpha1 is served")
+	default:
+		fmt.Printf("Test conversion is served by the operator in namespace %s, use --conversion-takeover to serve it from namespace %s\n", owner, cfg.Namespace)
+	}
+	cfg.Conversion = owner == cfg.Namespace
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/cmd/config"
+	"github.com/pkg/errors"
+)
+
+// setupMounts adds the secrets and config maps that get mounted into the test container. Command line options
+// override the mount path of objects already listed in the run configuration.
+func (o *testCmdOptions) setupMounts(test *v1alpha1.Test, runConfig *config.RunConfig) error {
+	var err error
+	if test.Spec.Secrets, err = mergeMounts(runConfig.Config.Runtime.Secrets, o.secrets); err != nil {
+		return err
+	}
+	if test.Spec.ConfigMaps, err = mergeMounts(runConfig.Config.Runtime.ConfigMaps, o.configMaps); err != nil {
+		return err
+	}
+	return nil
+}
+
+func mergeMounts(values ...[]string) ([]v1alpha1.MountSpec, error) {
+	var mounts []v1alpha1.MountSpec
+	index := make(map[string]int)
+
+	for _, list := range values {
+		for _, value := range list {
+			mount, err := parseMount(value)
+			if err != nil {
+				return nil, err
+			}
+
+			if i, ok := index[mount.Name]; ok {
+				mounts[i] = mount
+			} else {
+				index[mount.Name] = len(mounts)
+				mounts = append(mounts, mount)
+			}
+		}
+	}
+
+	return mounts, nil
+}
+
+// parseMount reads a mounted secret or config map in the format name[:path]
+func parseMount(value string) (v1alpha1.MountSpec, error) {
+	pair := strings.SplitN(strings.TrimSpace(value), ":", 2)
+	mount := v1alpha1.MountSpec{
+		Name: pair[0],
+	}
+	if len(pair) == 2 {
+		mount.Path = pair[1]
+	}
+
+	if mount.Name == "" {
+		return mount, errors.New(fmt.Sprintf("malformed mount '%s', expected name[:path]", value))
+	}
+	if mount.Path != "" && (!path.IsAbs(mount.Path) || mount.Path == "/") {
+		return mount, errors.New(fmt.Sprintf("invalid mount path '%s' of '%s', expected an absolute path", mount.Path, mount.Name))
+	}
+	if mount.Path != "" {
+		mount.Path = path.Clean(mount.Path)
+	}
+
+	return mount, nil
+}
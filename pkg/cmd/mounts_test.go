@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMount(t *testing.T) {
+	mount, err := parseMount("keystore")
+	assert.Nil(t, err)
+	assert.Equal(t, v1alpha1.MountSpec{Name: "keystore"}, mount)
+
+	mount, err = parseMount(" keystore:/opt/keystore/ ")
+	assert.Nil(t, err)
+	assert.Equal(t, v1alpha1.MountSpec{Name: "keystore", Path: "/opt/keystore"}, mount)
+
+	_, err = parseMount(":/opt/keystore")
+	assert.EqualError(t, err, "malformed mount ':/opt/keystore', expected name[:path]")
+
+	_, err = parseMount("keystore:opt/keystore")
+	assert.EqualError(t, err, "invalid mount path 'opt/keystore' of 'keystore', expected an absolute path")
+
+	_, err = parseMount("keystore:/")
+	assert.NotNil(t, err)
+}
+
+func TestMergeMounts(t *testing.T) {
+	mounts, err := mergeMounts([]string{"keystore", "data:/opt/data"}, []string{"keystore:/opt/keystore", "credentials"})
+	assert.Nil(t, err)
+	assert.Equal(t, []v1alpha1.MountSpec{
+		{Name: "keystore", Path: "/opt/keystore"},
+		{Name: "data", Path: "/opt/data"},
+		{Name: "credentials"},
+	}, mounts)
+
+	mounts, err = mergeMounts(nil, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, mounts)
+
+	_, err = mergeMounts([]string{"keystore"}, []string{""})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,396 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os/user"
+	"strconv"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/client"
+	"github.com/citrusframework/yaks/pkg/cmd/config"
+	"github.com/citrusframework/yaks/pkg/install"
+	"github.com/citrusframework/yaks/pkg/util/kubernetes"
+	"github.com/citrusframework/yaks/pkg/util/maven"
+	"github.com/citrusframework/yaks/pkg/util/openshift"
+	projectv1 "github.com/openshift/api/project/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// TemporaryLabel marks namespaces that have been created for a test run
+	TemporaryLabel = "org.citrusframework.yaks/temporary"
+	// CreatedByLabel holds the name of the user that has created the temporary namespace
+	CreatedByLabel = "org.citrusframework.yaks/created-by"
+	// CreatedAtLabel holds the creation time of the temporary namespace in seconds since epoch
+	CreatedAtLabel = "org.citrusframework.yaks/created-at"
+	// TTLLabel holds the time to live of the temporary namespace
+	TTLLabel = "org.citrusframework.yaks/ttl"
+
+	// operatorReadyTimeout is the time to wait for the operator in a temporary namespace before its limits get applied
+	operatorReadyTimeout = 5 * time.Minute
+)
+
+// tempNamespaceLabels returns the labels that identify a temporary namespace so that it can be garbage collected later
+func tempNamespaceLabels(namespaceConfig config.NamespaceConfig) map[string]string {
+	labels := make(map[string]string)
+	for k, v := range namespaceConfig.Labels {
+		labels[k] = v
+	}
+
+	labels[TemporaryLabel] = "true"
+	labels[CreatedAtLabel] = strconv.FormatInt(time.Now().Unix(), 10)
+	labels[TTLLabel] = namespaceConfig.GetTTL().String()
+
+	if current, err := user.Current(); err == nil {
+		if creator := kubernetes.SanitizeLabel(current.Username); creator != "" {
+			labels[CreatedByLabel] = creator
+		}
+	}
+
+	return labels
+}
+
+// isExpired returns true if the time to live of the given temporary namespace has passed
+func isExpired(namespace metav1.Object, now time.Time) bool {
+	createdAt, err := strconv.ParseInt(namespace.GetLabels()[CreatedAtLabel], 10, 64)
+	if err != nil {
+		return false
+	}
+
+	ttl, err := time.ParseDuration(namespace.GetLabels()[TTLLabel])
+	if err != nil {
+		return false
+	}
+
+	return time.Unix(createdAt, 0).Add(ttl).Before(now)
+}
+
+func initializeTempNamespace(name string, labels map[string]string, annotations map[string]string, c client.Client, context context.Context) (metav1.Object, error) {
+	var obj runtime.Object
+
+	oc, err := openshift.IsOpenShift(c)
+	if err != nil {
+		panic(err)
+	} else if oc {
+		scheme := c.GetScheme()
+		projectv1.AddToScheme(scheme)
+
+		obj = &projectv1.ProjectRequest{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: projectv1.GroupVersion.String(),
+				Kind:       "ProjectRequest",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}
+	} else {
+		obj = &corev1.Namespace{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+		}
+	}
+	fmt.Printf("Creating new test namespace %s\n", name)
+	if err = c.Create(context, obj); err != nil {
+		return obj.(metav1.Object), err
+	}
+
+	if oc && (len(labels) > 0 || len(annotations) > 0) {
+		// project requests do not propagate labels and annotations to the namespace
+		err = setNamespaceMetadata(context, c, name, labels, annotations)
+	}
+	return obj.(metav1.Object), err
+}
+
+func deleteTempNamespace(ns metav1.Object, c client.Client, context context.Context) error {
+	if oc, err := openshift.IsOpenShift(c); err != nil {
+		return err
+	} else if oc {
+		prj := &projectv1.Project{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: projectv1.GroupVersion.String(),
+				Kind:       "Project",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: ns.GetName(),
+			},
+		}
+		if err = c.Delete(context, prj); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to remove namespace %s", ns.GetName()))
+		}
+	} else {
+		if err = c.Delete(context, ns.(runtime.Object)); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to remove namespace %s", ns.GetName()))
+		}
+	}
+	if err := install.DeleteWebhooks(context, c, ns.GetName()); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to remove webhooks of namespace %s", ns.GetName()))
+	}
+	fmt.Printf("AutoRemove namespace %s\n", ns.GetName())
+	return nil
+}
+
+func newNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
+
+// setNamespaceMetadata adds the given labels and annotations to the namespace. Entries with an empty value are removed.
+func setNamespaceMetadata(ctx context.Context, c client.Client, name string, labels map[string]string, annotations map[string]string) error {
+	namespace := corev1.Namespace{}
+	if err := c.Get(ctx, k8sclient.ObjectKey{Name: name}, &namespace); err != nil {
+		return err
+	}
+
+	namespace.Labels = mergeMetadata(namespace.Labels, labels)
+	namespace.Annotations = mergeMetadata(namespace.Annotations, annotations)
+	return c.Update(ctx, &namespace)
+}
+
+func mergeMetadata(target map[string]string, entries map[string]string) map[string]string {
+	if target == nil {
+		target = make(map[string]string)
+	}
+	for k, v := range entries {
+		if v == "" {
+			delete(target, k)
+		} else {
+			target[k] = v
+		}
+	}
+	return target
+}
+
+// newNamespaceLimits creates the resource quota and limit range for a temporary namespace as configured
+func newNamespaceLimits(namespaceConfig config.NamespaceConfig) ([]runtime.Object, error) {
+	objects := make([]runtime.Object, 0)
+
+	if len(namespaceConfig.Quota) > 0 {
+		hard, err := toResourceList(namespaceConfig.Quota)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid namespace quota")
+		}
+
+		objects = append(objects, &corev1.ResourceQuota{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "ResourceQuota",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "yaks-quota",
+			},
+			Spec: corev1.ResourceQuotaSpec{
+				Hard: hard,
+			},
+		})
+	}
+
+	if !namespaceConfig.Limits.IsEmpty() {
+		limit := corev1.LimitRangeItem{
+			Type: corev1.LimitTypeContainer,
+		}
+
+		var err error
+		if limit.Default, err = toResourceList(namespaceConfig.Limits.Default); err != nil {
+			return nil, errors.Wrap(err, "invalid namespace default limits")
+		}
+		if limit.DefaultRequest, err = toResourceList(namespaceConfig.Limits.DefaultRequest); err != nil {
+			return nil, errors.Wrap(err, "invalid namespace default request limits")
+		}
+		if limit.Max, err = toResourceList(namespaceConfig.Limits.Max); err != nil {
+			return nil, errors.Wrap(err, "invalid namespace max limits")
+		}
+		if limit.Min, err = toResourceList(namespaceConfig.Limits.Min); err != nil {
+			return nil, errors.Wrap(err, "invalid namespace min limits")
+		}
+
+		objects = append(objects, &corev1.LimitRange{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "LimitRange",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "yaks-limits",
+			},
+			Spec: corev1.LimitRangeSpec{
+				Limits: []corev1.LimitRangeItem{limit},
+			},
+		})
+	}
+
+	return objects, nil
+}
+
+// tempOperatorConfiguration returns the configuration of the operator in a temporary namespace. The operator takes over
+// the Maven settings of the operator in the current namespace together with the config maps and secrets they reference.
+func tempOperatorConfiguration(o *RootCmdOptions, c client.Client, namespace string) (install.OperatorConfiguration, error) {
+	cfg := install.OperatorConfiguration{Namespace: namespace}
+
+	mavenConfig, err := install.OperatorMavenConfiguration(o.Context, c, o.Namespace)
+	if err != nil {
+		return cfg, errors.Wrap(err, fmt.Sprintf("failed to read Maven settings of the operator in namespace %s", o.Namespace))
+	}
+
+	spec := v1alpha1.MavenSpec{Mirror: mavenConfig.Mirror}
+	if mavenConfig.Settings != "" {
+		if spec.Settings, err = maven.ParseSettingsReference(mavenConfig.Settings); err != nil {
+			return cfg, err
+		}
+	}
+	if mavenConfig.MirrorSecret != "" {
+		spec.Username, spec.Password = maven.MirrorCredentials(mavenConfig.MirrorSecret)
+	}
+
+	if err := copyMavenResources(o.Context, c, o.Namespace, namespace, spec); err != nil {
+		return cfg, err
+	}
+
+	cfg.Maven = mavenConfig
+	return cfg, nil
+}
+
+// copyMavenResources copies the config map or secret holding the Maven settings and the mirror secret from one
+// namespace to another so that tests in a temporary namespace resolve the same Maven settings
+func copyMavenResources(ctx context.Context, c client.Client, from string, to string, spec v1alpha1.MavenSpec) error {
+	objects := make([]runtime.Object, 0)
+	if spec.Settings != nil && spec.Settings.ConfigMap != nil {
+		configMap := corev1.ConfigMap{}
+		if err := c.Get(ctx, k8sclient.ObjectKey{Namespace: from, Name: spec.Settings.ConfigMap.Name}, &configMap); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to copy Maven settings config map %s from namespace %s", spec.Settings.ConfigMap.Name, from))
+		}
+		objects = append(objects, &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Name: configMap.Name, Labels: configMap.Labels},
+			Data:       configMap.Data,
+			BinaryData: configMap.BinaryData,
+		})
+	}
+
+	secrets := make([]string, 0)
+	if spec.Settings != nil && spec.Settings.Secret != nil {
+		secrets = append(secrets, spec.Settings.Secret.Name)
+	}
+	if spec.Username != nil {
+		secrets = append(secrets, spec.Username.Name)
+	}
+	for _, name := range secrets {
+		secret := corev1.Secret{}
+		if err := c.Get(ctx, k8sclient.ObjectKey{Namespace: from, Name: name}, &secret); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to copy Maven secret %s from namespace %s", name, from))
+		}
+		objects = append(objects, &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: secret.Name, Labels: secret.Labels},
+			Type:       secret.Type,
+			Data:       secret.Data,
+		})
+	}
+
+	return kubernetes.ReplaceResourcesInNamespace(ctx, c, to, objects)
+}
+
+// applyNamespaceLimits applies the quota and limits to the temporary namespace once the operator in the namespace is
+// ready, so that the limits do not keep the operator pod from starting
+func applyNamespaceLimits(ctx context.Context, c client.Client, namespace string, limits []runtime.Object) error {
+	if len(limits) == 0 {
+		return nil
+	}
+
+	if err := install.WaitForOperator(ctx, c, namespace, operatorReadyTimeout); err != nil {
+		return err
+	}
+	return kubernetes.ReplaceResourcesInNamespace(ctx, c, namespace, limits)
+}
+
+func toResourceList(values map[string]string) (corev1.ResourceList, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	resources := make(corev1.ResourceList)
+	for name, value := range values {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		resources[corev1.ResourceName(name)] = quantity
+	}
+	return resources, nil
+}
+
+// listNamespaces returns all active namespaces matching the given list options. On OpenShift the
+// namespaces are read from the projects the current user has access to.
+func listNamespaces(ctx context.Context, c client.Client, opts ...k8sclient.ListOption) ([]corev1.Namespace, error) {
+	namespaces := make([]corev1.Namespace, 0)
+
+	if oc, err := openshift.IsOpenShift(c); err != nil {
+		return nil, err
+	} else if oc {
+		if err := projectv1.AddToScheme(c.GetScheme()); err != nil {
+			return nil, err
+		}
+
+		projects := projectv1.ProjectList{}
+		if err := c.List(ctx, &projects, opts...); err != nil {
+			return nil, err
+		}
+		for _, project := range projects.Items {
+			if project.Status.Phase == corev1.NamespaceActive {
+				namespace := newNamespace(project.Name)
+				namespace.ObjectMeta = project.ObjectMeta
+				namespace.Status.Phase = project.Status.Phase
+				namespaces = append(namespaces, *namespace)
+			}
+		}
+	} else {
+		list := corev1.NamespaceList{}
+		if err := c.List(ctx, &list, opts...); err != nil {
+			return nil, err
+		}
+		for _, namespace := range list.Items {
+			if namespace.Status.Phase == corev1.NamespaceActive {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	}
+
+	return namespaces, nil
+}
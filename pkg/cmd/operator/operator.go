@@ -12,7 +12,9 @@ import (
 	"k8s.io/client-go/rest"
 
 	"github.com/citrusframework/yaks/pkg/apis"
+	yaksconfig "github.com/citrusframework/yaks/pkg/config"
 	"github.com/citrusframework/yaks/pkg/controller"
+	"github.com/citrusframework/yaks/pkg/webhook"
 
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	kubemetrics "github.com/operator-framework/operator-sdk/pkg/kube-metrics"
@@ -35,6 +37,7 @@ var (
 	metricsHost               = "0.0.0.0"
 	metricsPort         int32 = 8383
 	operatorMetricsPort int32 = 8686
+	webhookPort               = 9443
 )
 var log = logf.Log.WithName("cmd")
 
@@ -88,12 +91,21 @@ func Run() {
 		os.Exit(1)
 	}
 
-	// Create a new Cmd to provide shared dependencies and start components
-	mgr, err := manager.New(cfg, manager.Options{
+	options := manager.Options{
 		Namespace:          namespace,
 		MapperProvider:     restmapper.NewDynamicRESTMapper,
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
-	})
+	}
+
+	// Serve the admission webhooks when a serving certificate has been provided
+	webhookCertDir := yaksconfig.GetWebhookCertDir()
+	if webhookCertDir != "" {
+		options.Port = webhookPort
+		options.CertDir = webhookCertDir
+	}
+
+	// Create a new Cmd to provide shared dependencies and start components
+	mgr, err := manager.New(cfg, options)
 	if err != nil {
 		log.Error(err, "")
 		os.Exit(1)
@@ -113,6 +125,14 @@ func Run() {
 		os.Exit(1)
 	}
 
+	// Setup all admission webhooks
+	if webhookCertDir != "" {
+		if err := webhook.AddToManager(mgr); err != nil {
+			log.Error(err, "")
+			os.Exit(1)
+		}
+	}
+
 	if err = serveCRMetrics(cfg); err != nil {
 		log.Info("Could not generate and serve custom resource metrics", "error", err.Error())
 	}
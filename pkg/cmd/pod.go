@@ -0,0 +1,252 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/cmd/config"
+	"github.com/citrusframework/yaks/pkg/util/maven"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// newRuntimeSpec creates the test runtime settings from the run configuration and the command line flags
+func (o *testCmdOptions) newRuntimeSpec(runConfig *config.RunConfig) (v1alpha1.RuntimeSpec, error) {
+	spec := v1alpha1.RuntimeSpec{
+		Image:           runConfig.Config.Runtime.Image,
+		ImagePullPolicy: corev1.PullPolicy(runConfig.Config.Runtime.ImagePullPolicy),
+	}
+
+	if o.image != "" {
+		spec.Image = o.image
+	}
+
+	if o.imagePullPolicy != "" {
+		spec.ImagePullPolicy = corev1.PullPolicy(o.imagePullPolicy)
+	}
+
+	switch spec.ImagePullPolicy {
+	case "", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+	default:
+		return spec, errors.New(fmt.Sprintf("unsupported image pull policy '%s', please use one of %s, %s, %s",
+			spec.ImagePullPolicy, corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever))
+	}
+
+	for _, secret := range append(runConfig.Config.Runtime.ImagePullSecrets, o.imagePullSecrets...) {
+		spec.ImagePullSecrets = append(spec.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
+
+	var err error
+	if spec.Maven, err = o.newMavenSpec(runConfig); err != nil {
+		return spec, err
+	}
+
+	spec.Pod, err = o.newPodSpec(runConfig)
+	return spec, err
+}
+
+// newMavenSpec merges the Maven settings from the run configuration and the command line flags
+func (o *testCmdOptions) newMavenSpec(runConfig *config.RunConfig) (v1alpha1.MavenSpec, error) {
+	spec := v1alpha1.MavenSpec{}
+
+	settings := runConfig.Config.Runtime.Maven.Settings
+	if o.mavenSettings != "" {
+		settings = o.mavenSettings
+	}
+	if settings != "" {
+		reference, err := maven.ParseSettingsReference(settings)
+		if err != nil {
+			return spec, err
+		}
+		spec.Settings = reference
+	}
+
+	spec.Mirror = runConfig.Config.Runtime.Maven.Mirror
+	if o.mavenMirror != "" {
+		spec.Mirror = o.mavenMirror
+	}
+
+	secret := runConfig.Config.Runtime.Maven.MirrorSecret
+	if o.mavenMirrorSecret != "" {
+		secret = o.mavenMirrorSecret
+	}
+	if secret != "" {
+		spec.Username, spec.Password = maven.MirrorCredentials(secret)
+	}
+
+	return spec, maven.ValidateSettings(spec)
+}
+
+// newPodSpec merges the pod settings from the run configuration, the pod spec file and the command line flags
+func (o *testCmdOptions) newPodSpec(runConfig *config.RunConfig) (v1alpha1.PodSpec, error) {
+	spec := v1alpha1.PodSpec{}
+	mergePodSpec(&spec, runConfig.Config.Runtime.Pod.PodSpec)
+
+	if o.podSpec != "" {
+		data, err := o.loadData(o.podSpec)
+		if err != nil {
+			return spec, err
+		}
+
+		podConfig := config.PodConfig{}
+		if err := yaml.Unmarshal([]byte(data), &podConfig); err != nil {
+			return spec, errors.Wrap(err, fmt.Sprintf("invalid pod spec file %s", o.podSpec))
+		}
+		mergePodSpec(&spec, podConfig.PodSpec)
+	}
+
+	flags := v1alpha1.PodSpec{
+		ServiceAccountName: o.serviceAccount,
+	}
+
+	var err error
+	if flags.Resources.Requests, err = parseResourceList(o.podRequests); err != nil {
+		return spec, err
+	}
+	if flags.Resources.Limits, err = parseResourceList(o.podLimits); err != nil {
+		return spec, err
+	}
+
+	if len(o.nodeSelector) > 0 {
+		flags.NodeSelector = make(map[string]string)
+		for _, selector := range o.nodeSelector {
+			pair := strings.SplitN(selector, "=", 2)
+			if len(pair) != 2 || pair[0] == "" {
+				return spec, errors.New(fmt.Sprintf("invalid node selector '%s', expected key=value", selector))
+			}
+			flags.NodeSelector[pair[0]] = pair[1]
+		}
+	}
+
+	for _, value := range o.tolerations {
+		toleration, err := parseToleration(value)
+		if err != nil {
+			return spec, err
+		}
+		flags.Tolerations = append(flags.Tolerations, toleration)
+	}
+
+	mergePodSpec(&spec, flags)
+	return spec, nil
+}
+
+// mergePodSpec merges the source settings into the target. Single values of the source override the target values,
+// lists get appended.
+func mergePodSpec(target *v1alpha1.PodSpec, source v1alpha1.PodSpec) {
+	target.Resources.Requests = mergeResourceList(target.Resources.Requests, source.Resources.Requests)
+	target.Resources.Limits = mergeResourceList(target.Resources.Limits, source.Resources.Limits)
+
+	if len(source.NodeSelector) > 0 {
+		if target.NodeSelector == nil {
+			target.NodeSelector = make(map[string]string)
+		}
+		for k, v := range source.NodeSelector {
+			target.NodeSelector[k] = v
+		}
+	}
+
+	target.Tolerations = append(target.Tolerations, source.Tolerations...)
+
+	if source.Affinity != nil {
+		target.Affinity = source.Affinity
+	}
+
+	if source.SecurityContext != nil {
+		target.SecurityContext = source.SecurityContext
+	}
+
+	if source.ServiceAccountName != "" {
+		target.ServiceAccountName = source.ServiceAccountName
+	}
+
+	target.Volumes = append(target.Volumes, source.Volumes...)
+	target.VolumeMounts = append(target.VolumeMounts, source.VolumeMounts...)
+}
+
+func mergeResourceList(target corev1.ResourceList, source corev1.ResourceList) corev1.ResourceList {
+	if len(source) == 0 {
+		return target
+	}
+
+	if target == nil {
+		target = make(corev1.ResourceList)
+	}
+	for name, quantity := range source {
+		target[name] = quantity
+	}
+	return target
+}
+
+// parseResourceList reads resource quantities given as name=quantity, e.g. "memory=512Mi"
+func parseResourceList(values []string) (corev1.ResourceList, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	resources := make(corev1.ResourceList)
+	for _, value := range values {
+		pair := strings.SplitN(value, "=", 2)
+		if len(pair) != 2 || pair[0] == "" {
+			return nil, errors.New(fmt.Sprintf("invalid resource '%s', expected name=quantity", value))
+		}
+
+		quantity, err := resource.ParseQuantity(pair[1])
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid resource '%s'", value))
+		}
+		resources[corev1.ResourceName(pair[0])] = quantity
+	}
+	return resources, nil
+}
+
+// parseToleration reads a toleration given in the format key[=value]:effect
+func parseToleration(value string) (corev1.Toleration, error) {
+	toleration := corev1.Toleration{}
+
+	idx := strings.LastIndex(value, ":")
+	if idx < 0 {
+		return toleration, errors.New(fmt.Sprintf("invalid toleration '%s', expected key[=value]:effect", value))
+	}
+
+	toleration.Effect = corev1.TaintEffect(value[idx+1:])
+	switch toleration.Effect {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		return toleration, errors.New(fmt.Sprintf("invalid toleration effect '%s'", toleration.Effect))
+	}
+
+	pair := strings.SplitN(value[:idx], "=", 2)
+	if pair[0] == "" {
+		return toleration, errors.New(fmt.Sprintf("invalid toleration '%s', expected key[=value]:effect", value))
+	}
+
+	toleration.Key = pair[0]
+	if len(pair) == 2 {
+		toleration.Operator = corev1.TolerationOpEqual
+		toleration.Value = pair[1]
+	} else {
+		toleration.Operator = corev1.TolerationOpExists
+	}
+
+	return toleration, nil
+}
@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/citrusframework/yaks/pkg/cmd/config"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewRuntimeSpecImage(t *testing.T) {
+	runConfig := config.NewWithDefaults()
+	runConfig.Config.Runtime.Image = "yaks-runtime:1.0"
+	runConfig.Config.Runtime.ImagePullPolicy = "IfNotPresent"
+	runConfig.Config.Runtime.ImagePullSecrets = []string{"config-secret"}
+
+	options := testCmdOptions{}
+	spec, err := options.newRuntimeSpec(runConfig)
+	assert.Nil(t, err)
+	assert.Equal(t, "yaks-runtime:1.0", spec.Image)
+	assert.Equal(t, corev1.PullIfNotPresent, spec.ImagePullPolicy)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "config-secret"}}, spec.ImagePullSecrets)
+
+	options = testCmdOptions{
+		image:            "yaks-runtime:2.0",
+		imagePullPolicy:  "Always",
+		imagePullSecrets: []string{"flag-secret"},
+	}
+	spec, err = options.newRuntimeSpec(runConfig)
+	assert.Nil(t, err)
+	assert.Equal(t, "yaks-runtime:2.0", spec.Image)
+	assert.Equal(t, corev1.PullAlways, spec.ImagePullPolicy)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "config-secret"}, {Name: "flag-secret"}}, spec.ImagePullSecrets)
+
+	options = testCmdOptions{imagePullPolicy: "Sometimes"}
+	_, err = options.newRuntimeSpec(config.NewWithDefaults())
+	assert.EqualError(t, err, "unsupported image pull policy 'Sometimes', please use one of Always, IfNotPresent, Never")
+}
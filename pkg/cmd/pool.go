@@ -0,0 +1,316 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/citrusframework/yaks/pkg/client"
+	"github.com/citrusframework/yaks/pkg/cmd/config"
+	"github.com/citrusframework/yaks/pkg/install"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// PoolLabel marks namespaces that belong to the namespace pool
+	PoolLabel = "org.citrusframework.yaks/pool"
+	// PoolStateLabel holds the state of a pooled namespace
+	PoolStateLabel = "org.citrusframework.yaks/pool-state"
+
+	PoolStateCreating = "creating"
+	PoolStateReady    = "ready"
+	PoolStateInUse    = "in-use"
+)
+
+func newCmdPool(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	options := poolCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		PersistentPreRunE: options.preRun,
+		Use:               "pool [options]",
+		Short:             "Manage the pool of pre-warmed temporary test namespaces",
+		Long:              `Fills the pool of temporary test namespaces so that each namespace has the YAKS operator and viewer roles installed and is ready to be handed out to test runs.`,
+		PreRunE:           options.validateArgs,
+		RunE:              options.run,
+		SilenceUsage:      true,
+	}
+
+	cmd.Flags().IntVar(&options.size, "size", 0, "Number of ready namespaces the pool should hold")
+	cmd.Flags().BoolVar(&options.drain, "drain", false, "Delete all ready namespaces of the pool")
+
+	return &cmd
+}
+
+type poolCmdOptions struct {
+	*RootCmdOptions
+	size  int
+	drain bool
+}
+
+func (o *poolCmdOptions) validateArgs(_ *cobra.Command, _ []string) error {
+	if o.size < 0 {
+		return errors.New(fmt.Sprintf("invalid pool size %d", o.size))
+	}
+
+	if o.size == 0 && !o.drain {
+		return errors.New("please specify either the pool size or the drain option")
+	}
+
+	return nil
+}
+
+func (o *poolCmdOptions) run(cmd *cobra.Command, _ []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	if o.drain {
+		ready, err := listPoolNamespaces(o.Context, c, PoolStateReady)
+		if err != nil {
+			return err
+		}
+
+		removed := 0
+		for _, name := range ready {
+			if err := deletePoolNamespace(name, c, o.Context); err != nil {
+				fmt.Fprintf(os.Stderr, "WARN: %v\n", err)
+				continue
+			}
+			removed++
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Removed %d namespaces from pool\n", removed)
+		if err == nil && removed < len(ready) {
+			err = errors.New(fmt.Sprintf("failed to remove %d namespaces from pool", len(ready)-removed))
+		}
+		return err
+	}
+
+	created, err := newNamespacePool(o.RootCmdOptions, c).fill(o.size)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Namespace pool ready (%d namespaces added)\n", created)
+	return err
+}
+
+// namespacePool hands out the namespaces of the pool to test runs. Handing out a namespace refills the pool in the
+// background while the tests are running. Used namespaces never return to the pool as they may hold any resource
+// created by the tests, they are removed instead.
+type namespacePool struct {
+	*RootCmdOptions
+	client client.Client
+	// create sets up a new namespace of the pool in the given state
+	create func(state string, labels map[string]string) (metav1.Object, error)
+
+	lock    sync.Mutex
+	filling bool
+	refills sync.WaitGroup
+}
+
+func newNamespacePool(o *RootCmdOptions, c client.Client) *namespacePool {
+	return &namespacePool{
+		RootCmdOptions: o,
+		client:         c,
+		create: func(state string, labels map[string]string) (metav1.Object, error) {
+			return createPoolNamespace(o, c, state, labels)
+		},
+	}
+}
+
+// acquire hands out a ready namespace from the pool. A new pooled namespace gets created in case the pool is
+// exhausted. Either way the pool gets refilled up to the given size in the background.
+func (p *namespacePool) acquire(size int, labels map[string]string) (metav1.Object, error) {
+	ready, err := listPoolNamespaces(p.Context, p.client, PoolStateReady)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range ready {
+		if err := setPoolState(p.Context, p.client, name, PoolStateReady, PoolStateInUse, labels); err == nil {
+			fmt.Printf("Using namespace %s from pool\n", name)
+			p.refill(size)
+			return newNamespace(name), nil
+		} else if !k8serrors.IsConflict(err) && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		// namespace has been claimed by another test run in the meantime, try the next one
+	}
+
+	fmt.Println("Namespace pool exhausted")
+	p.refill(size)
+	return p.create(PoolStateInUse, labels)
+}
+
+// release removes the namespace used by a test run. With remove disabled the namespace is kept for inspection but is
+// no longer part of the pool. The method waits for the background refill of the pool to finish.
+func (p *namespacePool) release(name string, remove bool) {
+	if remove {
+		if err := deletePoolNamespace(name, p.client, p.Context); err != nil {
+			fmt.Fprintf(os.Stderr, "WARN: %v\n", err)
+		}
+	} else {
+		if err := setNamespaceMetadata(p.Context, p.client, name, map[string]string{PoolLabel: "", PoolStateLabel: ""}, nil); err != nil {
+			fmt.Fprintf(os.Stderr, "WARN: Failed to remove namespace %s from pool: %v\n", name, err)
+		}
+		fmt.Printf("Keeping namespace %s\n", name)
+	}
+
+	p.wait()
+}
+
+// refill fills the pool up to the given size in the background. Only one refill runs at a time.
+func (p *namespacePool) refill(size int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.filling {
+		return
+	}
+
+	p.filling = true
+	p.refills.Add(1)
+	go func() {
+		defer p.refills.Done()
+		if _, err := p.fill(size); err != nil {
+			fmt.Fprintf(os.Stderr, "WARN: Failed to refill namespace pool: %v\n", err)
+		}
+
+		p.lock.Lock()
+		p.filling = false
+		p.lock.Unlock()
+	}()
+}
+
+// wait blocks until the background refill of the pool has finished
+func (p *namespacePool) wait() {
+	p.refills.Wait()
+}
+
+// fill creates ready namespaces until the pool has the given size. Namespaces that other test runs are creating for
+// the pool count as well, so that concurrent refills do not exceed the pool size.
+func (p *namespacePool) fill(size int) (int, error) {
+	ready, err := listPoolNamespaces(p.Context, p.client, PoolStateReady)
+	if err != nil {
+		return 0, err
+	}
+	creating, err := listPoolNamespaces(p.Context, p.client, PoolStateCreating)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for i := len(ready) + len(creating); i < size; i++ {
+		if _, err := p.create(PoolStateReady, tempNamespaceLabels(config.NamespaceConfig{})); err != nil {
+			return created, err
+		}
+		created++
+	}
+
+	return created, nil
+}
+
+// createPoolNamespace creates a namespace of the pool and installs the operator and the viewer roles. Ready namespaces
+// stay in the creating state until the installation has finished so that test runs never use them too early.
+func createPoolNamespace(o *RootCmdOptions, c client.Client, state string, labels map[string]string) (metav1.Object, error) {
+	name := "yaks-" + uuid.New().String()
+	initialState := state
+	if state == PoolStateReady {
+		initialState = PoolStateCreating
+	}
+	poolLabels := map[string]string{
+		PoolLabel:      "true",
+		PoolStateLabel: initialState,
+	}
+	for k, v := range labels {
+		poolLabels[k] = v
+	}
+
+	namespace, err := initializeTempNamespace(name, poolLabels, nil, c, o.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setupCluster(o); err != nil {
+		return namespace, err
+	}
+
+	operatorConfig, err := tempOperatorConfiguration(o, c, name)
+	if err != nil {
+		return namespace, err
+	}
+	if err := setupOperator(o, operatorConfig); err != nil {
+		return namespace, err
+	}
+
+	if err := install.ViewerServiceAccountRoles(o.Context, c, name); err != nil {
+		return namespace, err
+	}
+
+	if initialState != state {
+		return namespace, setPoolState(o.Context, c, name, initialState, state, nil)
+	}
+	return namespace, nil
+}
+
+func deletePoolNamespace(name string, c client.Client, ctx context.Context) error {
+	return deleteTempNamespace(newNamespace(name), c, ctx)
+}
+
+// setPoolState moves the pooled namespace from one state to another. The update fails with a conflict
+// when another client has changed the namespace in the meantime.
+func setPoolState(ctx context.Context, c client.Client, name string, from string, to string, labels map[string]string) error {
+	namespace := corev1.Namespace{}
+	if err := c.Get(ctx, k8sclient.ObjectKey{Name: name}, &namespace); err != nil {
+		return err
+	}
+
+	if namespace.Labels[PoolStateLabel] != from {
+		return k8serrors.NewConflict(corev1.Resource("namespaces"), name, errors.New("namespace has already been claimed"))
+	}
+
+	namespace.Labels = mergeMetadata(namespace.Labels, labels)
+	namespace.Labels[PoolStateLabel] = to
+	return c.Update(ctx, &namespace)
+}
+
+func listPoolNamespaces(ctx context.Context, c client.Client, state string) ([]string, error) {
+	namespaces, err := listNamespaces(ctx, c, k8sclient.MatchingLabels{
+		PoolLabel:      "true",
+		PoolStateLabel: state,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		names = append(names, namespace.Name)
+	}
+	return names, nil
+}
@@ -0,0 +1,182 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/citrusframework/yaks/pkg/apis"
+	"github.com/citrusframework/yaks/pkg/client"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientscheme "k8s.io/client-go/kubernetes/scheme"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeClient is a client backed by in-memory objects on a plain Kubernetes cluster
+type fakeClient struct {
+	k8sclient.Client
+	kubernetes.Interface
+	scheme *runtime.Scheme
+}
+
+func (c *fakeClient) GetScheme() *runtime.Scheme {
+	return c.scheme
+}
+
+func (c *fakeClient) Discovery() discovery.DiscoveryInterface {
+	return kubernetesDiscovery{c.Interface.Discovery().(*discoveryfake.FakeDiscovery)}
+}
+
+// kubernetesDiscovery reports that no OpenShift APIs are available
+type kubernetesDiscovery struct {
+	*discoveryfake.FakeDiscovery
+}
+
+func (d kubernetesDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	return nil, k8serrors.NewNotFound(schema.GroupResource{}, groupVersion)
+}
+
+func newFakeClient(objects ...runtime.Object) client.Client {
+	scheme := runtime.NewScheme()
+	if err := clientscheme.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	if err := apis.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+
+	return &fakeClient{
+		Client:    fake.NewFakeClientWithScheme(scheme, objects...),
+		Interface: kubefake.NewSimpleClientset(),
+		scheme:    scheme,
+	}
+}
+
+func newPoolNamespace(name string, state string) *corev1.Namespace {
+	namespace := newNamespace(name)
+	namespace.Labels = map[string]string{
+		PoolLabel:      "true",
+		PoolStateLabel: state,
+	}
+	namespace.Status.Phase = corev1.NamespaceActive
+	return namespace
+}
+
+// newTestPool creates a pool that records the namespaces it creates instead of installing the operator
+func newTestPool(c client.Client) (*namespacePool, func() []string) {
+	var lock sync.Mutex
+	created := make([]string, 0)
+
+	pool := newNamespacePool(&RootCmdOptions{Context: context.TODO()}, c)
+	pool.create = func(state string, labels map[string]string) (metav1.Object, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		created = append(created, state)
+		return newNamespace("yaks-new"), nil
+	}
+	return pool, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return created
+	}
+}
+
+func TestAcquireReadyNamespace(t *testing.T) {
+	c := newFakeClient(newPoolNamespace("yaks-ready", PoolStateReady), newPoolNamespace("yaks-used", PoolStateInUse))
+	pool, created := newTestPool(c)
+
+	namespace, err := pool.acquire(2, map[string]string{"team": "yaks"})
+	assert.Nil(t, err)
+	assert.Equal(t, "yaks-ready", namespace.GetName())
+
+	acquired := corev1.Namespace{}
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Name: "yaks-ready"}, &acquired))
+	assert.Equal(t, PoolStateInUse, acquired.Labels[PoolStateLabel])
+	assert.Equal(t, "yaks", acquired.Labels["team"])
+
+	pool.wait()
+	assert.Equal(t, []string{PoolStateReady, PoolStateReady}, created())
+}
+
+func TestAcquireExhaustedPool(t *testing.T) {
+	c := newFakeClient(newPoolNamespace("yaks-used", PoolStateInUse))
+	pool, created := newTestPool(c)
+
+	namespace, err := pool.acquire(1, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "yaks-new", namespace.GetName())
+
+	pool.wait()
+	assert.ElementsMatch(t, []string{PoolStateInUse, PoolStateReady}, created())
+}
+
+func TestAcquireClaimedNamespace(t *testing.T) {
+	c := newFakeClient(newPoolNamespace("yaks-used", PoolStateInUse))
+
+	err := setPoolState(context.TODO(), c, "yaks-used", PoolStateReady, PoolStateInUse, nil)
+	assert.True(t, k8serrors.IsConflict(err))
+}
+
+func TestReleaseNamespace(t *testing.T) {
+	c := newFakeClient(newPoolNamespace("yaks-used", PoolStateInUse))
+	pool, created := newTestPool(c)
+
+	pool.release("yaks-used", true)
+
+	err := c.Get(context.TODO(), k8sclient.ObjectKey{Name: "yaks-used"}, &corev1.Namespace{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	assert.Empty(t, created())
+}
+
+func TestReleaseKeepsNamespace(t *testing.T) {
+	c := newFakeClient(newPoolNamespace("yaks-used", PoolStateInUse))
+	pool, _ := newTestPool(c)
+
+	pool.release("yaks-used", false)
+
+	kept := corev1.Namespace{}
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Name: "yaks-used"}, &kept))
+	assert.NotContains(t, kept.Labels, PoolLabel)
+	assert.NotContains(t, kept.Labels, PoolStateLabel)
+
+	ready, err := listPoolNamespaces(context.TODO(), c, PoolStateInUse)
+	assert.Nil(t, err)
+	assert.Empty(t, ready)
+}
+
+func TestFillNamespacePool(t *testing.T) {
+	c := newFakeClient(newPoolNamespace("yaks-ready", PoolStateReady), newPoolNamespace("yaks-creating", PoolStateCreating))
+	pool, created := newTestPool(c)
+
+	count, err := pool.fill(3)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []string{PoolStateReady}, created())
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
 	"github.com/citrusframework/yaks/pkg/cmd/report"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -25,6 +26,7 @@ func newCmdReport(rootCmdOptions *RootCmdOptions) *cobra.Command {
 	cmd.Flags().BoolVar(&options.fetch, "fetch", false, "Fetch latest test results from cluster.")
 	cmd.Flags().VarP(&options.output, "output", "o", "The report output format, one of 'summary', 'json', 'junit'")
 	cmd.Flags().BoolVarP(&options.clean, "clean", "c", false,"Clean the report output folder before fetching results")
+	cmd.Flags().StringArrayVar(&options.merge, "merge", nil, "Merge the test results of another output folder, e.g. the results of a test shard")
 
 	return &cmd
 }
@@ -33,11 +35,22 @@ type reportCmdOptions struct {
 	*RootCmdOptions
 	clean bool
 	fetch bool
+	merge []string
 	output report.OutputFormat
 }
 
 func (o *reportCmdOptions) run(cmd *cobra.Command, _ []string) error {
 	var results v1alpha1.TestResults
+	if o.merge != nil {
+		if o.fetch {
+			return errors.New("option --merge cannot be combined with --fetch")
+		}
+
+		if err := report.MergeResults(o.merge); err != nil {
+			return err
+		}
+	}
+
 	if o.fetch {
 		if fetched, err := o.FetchResults(); err == nil {
 			results = *fetched
@@ -82,7 +95,7 @@ func (o *reportCmdOptions) FetchResults() (*v1alpha1.TestResults, error) {
 	}
 
 	for _, test := range testList.Items {
-		report.AppendTestResults(&results, test.Status.Results)
+		report.AppendTestResults(&results, report.TestResultsFor(&test))
 		if err := report.SaveTestResults(&test); err != nil {
 			fmt.Printf("Failed to save test results: %s", err.Error())
 		}
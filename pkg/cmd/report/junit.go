@@ -30,9 +30,24 @@ type TestCase struct {
 	ClassName string `xml:"classname,attr"`
 	Time float32 `xml:"time,attr"`
 	SystemOut string `xml:"system-out,omitempty"`
+	Properties *Properties `xml:"properties,omitempty"`
+	Skipped *Skipped `xml:"skipped,omitempty"`
 	Failure *Failure
 }
 
+type Skipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+type Properties struct {
+	Property []Property `xml:"property"`
+}
+
+type Property struct {
+	Name string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
 type Failure struct {
 	XMLName xml.Name `xml:"failure,omitempty"`
 	Message string `xml:"message,attr,omitempty"`
@@ -65,6 +80,16 @@ func createJUnitReport(results *v1alpha1.TestResults, outputDir string) (string,
 			}
 		}
 
+		if result.Skipped {
+			testCase.Skipped = &Skipped{}
+		}
+
+		if result.Flaky {
+			testCase.Properties = &Properties{
+				Property: []Property{{Name: "flaky", Value: "true"}},
+			}
+		}
+
 		report.Suite.TestCase = append(report.Suite.TestCase, testCase)
 	}
 
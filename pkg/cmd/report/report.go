@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"strings"
 )
 
 type OutputFormat string
@@ -70,23 +71,46 @@ func AppendTestResults(results *v1alpha1.TestResults, result v1alpha1.TestResult
 	results.Summary.Failed += result.Summary.Failed
 	results.Summary.Skipped += result.Summary.Skipped
 	results.Summary.Undefined += result.Summary.Undefined
+	results.Summary.Flaky += result.Summary.Flaky
 	results.Summary.Pending += result.Summary.Pending
 	results.Summary.Total += result.Summary.Total
 
 	for _, result := range result.Tests {
 		results.Tests = append(results.Tests, result)
 	}
+
+	results.Errors = append(results.Errors, result.Errors...)
+}
+
+// SkippedTestResults returns the results of a test that has not been run
+func SkippedTestResults(name string) v1alpha1.TestResults {
+	return v1alpha1.TestResults{
+		Summary: v1alpha1.TestSummary{Total: 1, Skipped: 1},
+		Tests:   []v1alpha1.TestResult{{Name: name, Skipped: true}},
+	}
+}
+
+// TestResultsFor returns the results of the given test including the errors reported in the test status
+func TestResultsFor(test *v1alpha1.Test) v1alpha1.TestResults {
+	results := *test.Status.Results.DeepCopy()
+	results.Errors = append(results.Errors, test.Status.Errors...)
+	return results
 }
 
 func SaveTestResults(test *v1alpha1.Test) error {
+	return SaveResults(test.Name, TestResultsFor(test))
+}
+
+// SaveResults stores the results of the test with the given name in the output directory
+func SaveResults(name string, results v1alpha1.TestResults) error {
 	outputDir, err := createInWorkingDir(OutputDir)
 
-	reportFile, err := os.Create(path.Join(outputDir, kubernetes.SanitizeName(test.Name)) + ".json")
+	reportFile, err := os.Create(path.Join(outputDir, kubernetes.SanitizeName(name)) + ".json")
 	if err != nil {
 		return err
 	}
 
-	bytes, _ := json.Marshal(test.Status.Results)
+	bytes, _ := json.Marshal(results)
 	if _, err := reportFile.Write(bytes); err != nil {
 		return err
 	}
@@ -94,6 +118,75 @@ func SaveTestResults(test *v1alpha1.Test) error {
 	return nil
 }
 
+// LoadResults reads the results of the test with the given name from the output directory. It returns nil when there
+// are no results of a previous run.
+func LoadResults(name string) (*v1alpha1.TestResults, error) {
+	outputDir, err := getInWorkingDir(OutputDir)
+	if err != nil && os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return LoadResultsFrom(outputDir, name)
+}
+
+// LoadResultsFrom reads the results of the test with the given name from the given directory. It returns nil when the
+// directory holds no results of the test.
+func LoadResultsFrom(dir string, name string) (*v1alpha1.TestResults, error) {
+	content, err := ioutil.ReadFile(path.Join(dir, kubernetes.SanitizeName(name)) + ".json")
+	if err != nil && os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var results v1alpha1.TestResults
+	if err := json.Unmarshal(content, &results); err != nil {
+		return nil, err
+	}
+
+	return &results, nil
+}
+
+// MergeResults copies the test results found in the given directories into the output directory, e.g. the output
+// directories of several shards that ran the tests of a single test run
+func MergeResults(dirs []string) error {
+	outputDir, err := createInWorkingDir(OutputDir)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range dirs {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			if file.IsDir() || path.Ext(file.Name()) != ".json" {
+				continue
+			}
+
+			content, err := ioutil.ReadFile(path.Join(dir, file.Name()))
+			if err != nil {
+				return err
+			}
+
+			var result v1alpha1.TestResults
+			if err := json.Unmarshal(content, &result); err != nil {
+				return errors.New(fmt.Sprintf("invalid test results in '%s': %s", path.Join(dir, file.Name()), err.Error()))
+			}
+
+			if err := ioutil.WriteFile(path.Join(outputDir, file.Name()), content, 0644); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func CleanReports() error {
 	err := removeFromWorkingDir(OutputDir)
 	return err
@@ -145,20 +238,26 @@ func PrintSummaryReport(results *v1alpha1.TestResults) {
 func GetSummaryReport(results *v1alpha1.TestResults) string {
 	summary := fmt.Sprintf("Test results: Total: %d, Passed: %d, Failed: %d, Skipped: %d\n",
 		results.Summary.Total, results.Summary.Passed, results.Summary.Failed, results.Summary.Skipped)
+	if results.Summary.Flaky > 0 {
+		summary = fmt.Sprintf("%s, Flaky: %d\n", strings.TrimSuffix(summary, "\n"), results.Summary.Flaky)
+	}
 
 	for _, test := range results.Tests {
 		result := "Passed"
 		if len(test.ErrorMessage) > 0 {
 			result = fmt.Sprintf("Failure caused by %s - %s", test.ErrorType, test.ErrorMessage)
+		} else if test.Skipped {
+			result = "Skipped"
+		} else if test.Flaky {
+			result = "Passed (flaky)"
 		}
 		summary += fmt.Sprintf("\t%s: %s\n", test.Name, result)
 	}
 
 	if len(results.Errors) > 0 {
-		if prettyPrint, err := json.MarshalIndent(results.Errors, "", "  "); err == nil {
-			summary += fmt.Sprintf("\nErrors: \n%s", string(prettyPrint))
-		} else {
-			fmt.Printf("Failed to read error details from test results: %s", err.Error())
+		summary += "\nErrors:\n"
+		for _, testError := range results.Errors {
+			summary += fmt.Sprintf("\t%s\n", testError.String())
 		}
 	}
 	return summary
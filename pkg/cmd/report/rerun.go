@@ -0,0 +1,101 @@
+package report
+
+import (
+	"path"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+)
+
+// FailedScenarios returns the names of the failed scenarios in the given results. The names use the format uri:line
+// and can be passed to Cucumber as feature filter. The second return value tells whether the test failed as a whole,
+// e.g. because of a timeout, or has been skipped so that no scenario filter applies.
+func FailedScenarios(results v1alpha1.TestResults) ([]string, bool) {
+	failed := make([]string, 0)
+	for _, result := range results.Tests {
+		if result.Skipped {
+			return nil, true
+		}
+		if result.ErrorType != "" {
+			failed = append(failed, result.Name)
+		}
+	}
+
+	if len(failed) == 0 && (len(results.Errors) > 0 || results.Summary.Failed > 0) {
+		return nil, true
+	}
+
+	return failed, false
+}
+
+// MergeTestResults merges the results of a rerun into the results of the previous run. Scenarios that have been run
+// again replace their previous result, errors of the previous run are kept only for scenarios that did not run again.
+// When the rerun did not use a scenario filter the rerun results replace the previous results completely.
+func MergeTestResults(previous v1alpha1.TestResults, rerun v1alpha1.TestResults, filtered bool) v1alpha1.TestResults {
+	if !filtered {
+		return rerun
+	}
+
+	rerunNames := make(map[string]bool)
+	rerunLocations := make(map[string]bool)
+	for _, result := range rerun.Tests {
+		rerunNames[result.Name] = true
+		_, location := path.Split(result.Name)
+		rerunLocations[location] = true
+	}
+
+	merged := v1alpha1.TestResults{
+		Summary:  previous.Summary,
+		Duration: sumDurations(previous.Duration, rerun.Duration),
+	}
+
+	for _, result := range previous.Tests {
+		if !rerunNames[result.Name] {
+			merged.Tests = append(merged.Tests, result)
+			continue
+		}
+
+		merged.Summary.Total--
+		if result.Flaky {
+			merged.Summary.Flaky--
+		}
+		if result.ErrorType != "" {
+			merged.Summary.Failed--
+		} else {
+			merged.Summary.Passed--
+		}
+	}
+	merged.Tests = append(merged.Tests, rerun.Tests...)
+
+	merged.Summary.Total += rerun.Summary.Total
+	merged.Summary.Passed += rerun.Summary.Passed
+	merged.Summary.Failed += rerun.Summary.Failed
+	merged.Summary.Skipped += rerun.Summary.Skipped
+	merged.Summary.Pending += rerun.Summary.Pending
+	merged.Summary.Undefined += rerun.Summary.Undefined
+	merged.Summary.Flaky += rerun.Summary.Flaky
+
+	for _, testError := range previous.Errors {
+		if testError.Location != "" && !rerunLocations[testError.Location] {
+			merged.Errors = append(merged.Errors, testError)
+		}
+	}
+	merged.Errors = append(merged.Errors, rerun.Errors...)
+
+	return merged
+}
+
+// sumDurations adds up the durations of both runs as the merged results cover the time spent in both runs. Durations
+// that cannot be parsed are ignored.
+func sumDurations(previous string, rerun string) string {
+	var total time.Duration
+	for _, value := range []string{previous, rerun} {
+		if duration, err := time.ParseDuration(value); err == nil {
+			total += duration
+		}
+	}
+	if total == 0 {
+		return ""
+	}
+	return total.String()
+}
@@ -0,0 +1,68 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+const featureURI = "classpath:org/citrusframework/yaks/order.feature"
+
+func previousResults() v1alpha1.TestResults {
+	return v1alpha1.TestResults{
+		Summary:  v1alpha1.TestSummary{Total: 3, Passed: 1, Failed: 2, Flaky: 1},
+		Duration: "2m0s",
+		Tests: []v1alpha1.TestResult{
+			{Name: featureURI + ":3", Scenario: "Create"},
+			{Name: featureURI + ":9", Scenario: "Update", ErrorType: "ValidationException", ErrorMessage: "boom", Flaky: true},
+			{Name: featureURI + ":15", Scenario: "Delete", ErrorType: "ValidationException", ErrorMessage: "boom"},
+		},
+		Errors: v1alpha1.TestErrors{
+			{Scenario: "Update", Location: "order.feature:9"},
+			{Scenario: "Delete", Location: "order.feature:15"},
+		},
+	}
+}
+
+func TestFailedScenarios(t *testing.T) {
+	scenarios, failed := FailedScenarios(previousResults())
+	assert.False(t, failed)
+	assert.Equal(t, []string{featureURI + ":9", featureURI + ":15"}, scenarios)
+
+	scenarios, failed = FailedScenarios(v1alpha1.TestResults{Errors: v1alpha1.TestErrors{{Type: "Timeout"}}})
+	assert.True(t, failed)
+	assert.Empty(t, scenarios)
+
+	scenarios, failed = FailedScenarios(SkippedTestResults("order.feature"))
+	assert.True(t, failed)
+	assert.Empty(t, scenarios)
+
+	scenarios, failed = FailedScenarios(v1alpha1.TestResults{Summary: v1alpha1.TestSummary{Total: 1, Passed: 1}})
+	assert.False(t, failed)
+	assert.Empty(t, scenarios)
+}
+
+func TestMergeTestResults(t *testing.T) {
+	rerun := v1alpha1.TestResults{
+		Summary:  v1alpha1.TestSummary{Total: 2, Passed: 1, Failed: 1},
+		Duration: "30s",
+		Tests: []v1alpha1.TestResult{
+			{Name: featureURI + ":9", Scenario: "Update"},
+			{Name: featureURI + ":15", Scenario: "Delete", ErrorType: "ValidationException", ErrorMessage: "again"},
+		},
+		Errors: v1alpha1.TestErrors{
+			{Scenario: "Delete", Location: "order.feature:15", Message: "again"},
+		},
+	}
+
+	merged := MergeTestResults(previousResults(), rerun, true)
+	assert.Equal(t, v1alpha1.TestSummary{Total: 3, Passed: 2, Failed: 1}, merged.Summary)
+	assert.Equal(t, "2m30s", merged.Duration)
+	assert.Len(t, merged.Tests, 3)
+	assert.Equal(t, featureURI+":3", merged.Tests[0].Name)
+	assert.Len(t, merged.Errors, 1)
+	assert.Equal(t, "again", merged.Errors[0].Message)
+
+	assert.Equal(t, rerun, MergeTestResults(previousResults(), rerun, false))
+}
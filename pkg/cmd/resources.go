@@ -0,0 +1,330 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/client"
+	"github.com/citrusframework/yaks/pkg/cmd/config"
+	"github.com/citrusframework/yaks/pkg/util/glob"
+	"github.com/citrusframework/yaks/pkg/util/kubernetes"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// maxInlineTestSize is the maximum size of the content kept inline in the test custom resource
+	maxInlineTestSize = 512 * 1024
+	// maxBundleSize is the maximum size of test content stored in a single config map
+	maxBundleSize = 900 * 1024
+)
+
+// testResource is a resource file to add to the test together with the path relative to the tests path
+type testResource struct {
+	file string
+	name string
+}
+
+// addTestResources adds the resources given in the run configuration and on the command line to the test. Feature files
+// are added as additional sources. All files keep their path relative to the test base directory.
+func (o *testCmdOptions) addTestResources(test *v1alpha1.Test, source string, runConfig *config.RunConfig) error {
+	patterns := make([]string, 0, len(runConfig.Config.Runtime.Resources)+len(o.resources))
+	for _, resource := range runConfig.Config.Runtime.Resources {
+		if !isRemoteFile(resource) && !path.IsAbs(resource) {
+			resource = path.Join(runConfig.BaseDir, resource)
+		}
+		patterns = append(patterns, resource)
+	}
+	patterns = append(patterns, o.resources...)
+
+	resources, err := resolveResources(patterns, runConfig.BaseDir)
+	if err != nil {
+		return err
+	}
+
+	names := map[string]bool{
+		test.Spec.Source.Name: true,
+	}
+	for _, resource := range resources {
+		if !isRemoteFile(source) && filepath.Clean(resource.file) == filepath.Clean(source) {
+			// the test source itself
+			continue
+		}
+
+		if names[resource.name] {
+			return errors.New(fmt.Sprintf("duplicate test resource '%s'", resource.name))
+		}
+		names[resource.name] = true
+
+		content, err := o.loadData(resource.file)
+		if err != nil {
+			return err
+		}
+
+		if strings.HasSuffix(resource.name, FileSuffix) {
+			test.Spec.Sources = append(test.Spec.Sources, v1alpha1.SourceSpec{
+				Name:     resource.name,
+				Content:  content,
+				Language: v1alpha1.LanguageGherkin,
+			})
+		} else {
+			test.Spec.Resources = append(test.Spec.Resources, v1alpha1.ResourceSpec{
+				Name:    resource.name,
+				Content: content,
+			})
+		}
+	}
+
+	return nil
+}
+
+// resolveResources expands the given files, directories and glob patterns to the list of resource files
+func resolveResources(patterns []string, baseDir string) ([]testResource, error) {
+	resources := make([]testResource, 0)
+	known := make(map[string]bool)
+
+	add := func(file string, root string) {
+		if known[file] {
+			return
+		}
+		known[file] = true
+		resources = append(resources, testResource{
+			file: file,
+			name: resourceName(file, baseDir, root),
+		})
+	}
+
+	for _, pattern := range patterns {
+		if isRemoteFile(pattern) {
+			add(pattern, "")
+			continue
+		}
+
+		matches := []string{pattern}
+		if glob.HasMeta(pattern) {
+			var err error
+			if matches, err = glob.Glob(pattern); err != nil {
+				return nil, err
+			} else if len(matches) == 0 {
+				return nil, errors.New(fmt.Sprintf("no resource found matching '%s'", pattern))
+			}
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+
+			if !info.IsDir() {
+				add(match, filepath.Dir(match))
+				continue
+			}
+
+			// directories keep their name and the relative path of the files within
+			root := filepath.Dir(filepath.Clean(match))
+			err = filepath.Walk(match, func(file string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if !info.IsDir() {
+					add(file, root)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return resources, nil
+}
+
+// resourceName returns the path of the resource relative to the base directory. Resources outside of the base directory
+// use the path relative to the given root.
+func resourceName(file string, baseDir string, root string) string {
+	if isRemoteFile(file) {
+		return path.Base(file)
+	}
+
+	if baseDir == "" {
+		baseDir = "."
+	}
+
+	if rel, err := filepath.Rel(baseDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+
+	if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+
+	return filepath.Base(file)
+}
+
+// bundleTestResources moves the content of tests exceeding the given inline size into config maps so that the test
+// custom resource stays below the size limit. This covers the main source and the settings file as well as the additional sources and resources.
+// The test references the config map keys instead of the inline content. Files exceeding the size of a single config
+// map are split into several parts that get concatenated again when the test runs.
+func bundleTestResources(test *v1alpha1.Test, maxInline int) ([]*corev1.ConfigMap, error) {
+	size := len(test.Spec.Source.Content) + len(test.Spec.Settings.Content)
+	for _, source := range test.Spec.Sources {
+		size += len(source.Content)
+	}
+	for _, resource := range test.Spec.Resources {
+		size += len(resource.Content)
+	}
+
+	if size <= maxInline {
+		return nil, nil
+	}
+
+	bundles := make([]*corev1.ConfigMap, 0)
+	var bundle *corev1.ConfigMap
+	bundleSize := 0
+
+	store := func(name string, content string) []corev1.ConfigMapKeySelector {
+		parts := make([]corev1.ConfigMapKeySelector, 0, 1)
+		for {
+			chunk := content
+			if len(chunk) > maxBundleSize {
+				// never split a multi byte character
+				end := maxBundleSize
+				for end > 0 && !utf8.RuneStart(chunk[end]) {
+					end--
+				}
+				chunk = chunk[:end]
+			}
+
+			if bundle == nil || bundleSize+len(chunk) > maxBundleSize {
+				bundle = newResourceBundle(test, len(bundles))
+				bundles = append(bundles, bundle)
+				bundleSize = 0
+			}
+
+			key := kubernetes.SanitizeConfigMapKey(name)
+			for i := 1; ; i++ {
+				if _, exists := bundle.Data[key]; !exists {
+					break
+				}
+				key = fmt.Sprintf("%s-%d", kubernetes.SanitizeConfigMapKey(name), i)
+			}
+			bundle.Data[key] = chunk
+			bundleSize += len(chunk)
+
+			parts = append(parts, corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: bundle.Name,
+				},
+				Key: key,
+			})
+
+			content = content[len(chunk):]
+			if content == "" {
+				return parts
+			}
+		}
+	}
+
+	if test.Spec.Source.Content != "" {
+		test.Spec.Source.Parts = store(test.Spec.Source.Name, test.Spec.Source.Content)
+		test.Spec.Source.Content = ""
+	}
+
+	if test.Spec.Settings.Name != "" && test.Spec.Settings.Content != "" {
+		test.Spec.Settings.Parts = store(test.Spec.Settings.Name, test.Spec.Settings.Content)
+		test.Spec.Settings.Content = ""
+	}
+
+	for i := range test.Spec.Sources {
+		source := &test.Spec.Sources[i]
+		if source.Content != "" {
+			source.Parts = store(source.Name, source.Content)
+			source.Content = ""
+		}
+	}
+
+	for i := range test.Spec.Resources {
+		resource := &test.Spec.Resources[i]
+		if resource.ConfigMap != nil || resource.Secret != nil || resource.Content == "" {
+			continue
+		}
+
+		parts := store(resource.Name, resource.Content)
+		if len(parts) == 1 {
+			resource.ConfigMap = &parts[0]
+		} else {
+			resource.Parts = parts
+		}
+		resource.Content = ""
+	}
+
+	return bundles, nil
+}
+
+func newResourceBundle(test *v1alpha1.Test, index int) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: test.Namespace,
+			Name:      fmt.Sprintf("yaks-bundle-%s-%d", test.Name, index),
+			Labels: map[string]string{
+				"org.citrusframework.yaks/app":  "yaks",
+				"org.citrusframework.yaks/test": test.Name,
+			},
+		},
+		Data: make(map[string]string),
+	}
+}
+
+// createResourceBundles creates the config maps holding the test resources. The owner, a test or test suite, must
+// exist already so that the config maps get garbage collected with the owner.
+func createResourceBundles(ctx context.Context, c client.Client, owner metav1.OwnerReference, bundles []*corev1.ConfigMap) error {
+	objects := make([]runtime.Object, 0, len(bundles))
+	for _, bundle := range bundles {
+		bundle.OwnerReferences = []metav1.OwnerReference{owner}
+		objects = append(objects, bundle)
+	}
+	return kubernetes.ReplaceResources(ctx, c, objects)
+}
+
+// ownerReferenceFor returns a reference to the given test or test suite
+func ownerReferenceFor(obj runtime.Object) metav1.OwnerReference {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	meta := obj.(metav1.Object)
+	return metav1.OwnerReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       meta.GetName(),
+		UID:        meta.GetUID(),
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/cmd/config"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBundleSmallTest(t *testing.T) {
+	test := &v1alpha1.Test{
+		Spec: v1alpha1.TestSpec{
+			Source: v1alpha1.SourceSpec{Name: "hello.feature", Content: "Feature: Hello"},
+		},
+	}
+
+	bundles, err := bundleTestResources(test, maxInlineTestSize)
+	assert.Nil(t, err)
+	assert.Empty(t, bundles)
+	assert.Equal(t, "Feature: Hello", test.Spec.Source.Content)
+}
+
+func TestBundleLargeTest(t *testing.T) {
+	large := strings.Repeat("a", 2*maxBundleSize+10)
+	test := &v1alpha1.Test{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello"},
+		Spec: v1alpha1.TestSpec{
+			Source:   v1alpha1.SourceSpec{Name: "hello.feature", Content: "Feature: Hello"},
+			Settings: v1alpha1.SettingsSpec{Name: "yaks-config.yaml", Content: "dependencies: []"},
+			Resources: []v1alpha1.ResourceSpec{
+				{Name: "data/large.txt", Content: large},
+				{Name: "small.txt", Content: "small"},
+			},
+		},
+	}
+
+	bundles, err := bundleTestResources(test, maxInlineTestSize)
+	assert.Nil(t, err)
+	assert.Len(t, bundles, 4)
+
+	content := func(name string, key string) string {
+		for _, bundle := range bundles {
+			if bundle.Name == name {
+				return bundle.Data[key]
+			}
+		}
+		return ""
+	}
+
+	assert.Empty(t, test.Spec.Source.Content)
+	assert.Len(t, test.Spec.Source.Parts, 1)
+	assert.Equal(t, "Feature: Hello", content(test.Spec.Source.Parts[0].Name, test.Spec.Source.Parts[0].Key))
+
+	assert.Empty(t, test.Spec.Settings.Content)
+	assert.Len(t, test.Spec.Settings.Parts, 1)
+
+	resource := test.Spec.Resources[0]
+	assert.Empty(t, resource.Content)
+	assert.Nil(t, resource.ConfigMap)
+	assert.Len(t, resource.Parts, 3)
+	joined := ""
+	for _, part := range resource.Parts {
+		assert.True(t, len(content(part.Name, part.Key)) <= maxBundleSize)
+		joined += content(part.Name, part.Key)
+	}
+	assert.Equal(t, large, joined)
+
+	assert.Empty(t, test.Spec.Resources[1].Content)
+	assert.NotNil(t, test.Spec.Resources[1].ConfigMap)
+	assert.Equal(t, "small", content(test.Spec.Resources[1].ConfigMap.Name, test.Spec.Resources[1].ConfigMap.Key))
+
+	for _, bundle := range bundles {
+		size := 0
+		for _, value := range bundle.Data {
+			size += len(value)
+		}
+		assert.True(t, size <= maxBundleSize)
+	}
+}
+
+func TestBundleKeepsCharacters(t *testing.T) {
+	large := strings.Repeat("ä", maxBundleSize)
+	test := &v1alpha1.Test{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello"},
+		Spec: v1alpha1.TestSpec{
+			Source: v1alpha1.SourceSpec{Name: "hello.feature", Content: large},
+		},
+	}
+
+	bundles, err := bundleTestResources(test, maxInlineTestSize)
+	assert.Nil(t, err)
+
+	joined := ""
+	for _, part := range test.Spec.Source.Parts {
+		for _, bundle := range bundles {
+			if bundle.Name == part.Name {
+				assert.True(t, utf8.ValidString(bundle.Data[part.Key]))
+				joined += bundle.Data[part.Key]
+			}
+		}
+	}
+	assert.Equal(t, large, joined)
+}
+
+func TestResourceName(t *testing.T) {
+	assert.Equal(t, "data/payload.json", resourceName("tests/data/payload.json", "tests/", "tests"))
+	assert.Equal(t, "payload.json", resourceName("payload.json", "", "."))
+	assert.Equal(t, "shared/payload.json", resourceName("../shared/payload.json", "tests/", ".."))
+	assert.Equal(t, "payload.json", resourceName("https://example.com/data/payload.json", "tests/", ""))
+}
+
+func TestAddTestResources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yaks-resources-*")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "data", "nested"), 0755))
+	files := map[string]string{
+		"hello.feature":            "Feature: Hello",
+		"other.feature":            "Feature: Other",
+		"data/payload.json":        "{}",
+		"data/nested/script.sh":    "echo",
+		"extra/ignored.properties": "",
+	}
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "extra"), 0755))
+	for name, content := range files {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	source := filepath.Join(dir, "hello.feature")
+	runConfig := config.NewWithDefaults()
+	runConfig.BaseDir = getBaseDir(source)
+	runConfig.Config.Runtime.Resources = []string{"data", "*.feature"}
+
+	test := &v1alpha1.Test{
+		Spec: v1alpha1.TestSpec{
+			Source: v1alpha1.SourceSpec{Name: "hello.feature", Content: "Feature: Hello"},
+		},
+	}
+	options := testCmdOptions{}
+	assert.Nil(t, options.addTestResources(test, source, runConfig))
+
+	assert.Equal(t, []v1alpha1.SourceSpec{
+		{Name: "other.feature", Content: "Feature: Other", Language: v1alpha1.LanguageGherkin},
+	}, test.Spec.Sources)
+	assert.Equal(t, []v1alpha1.ResourceSpec{
+		{Name: "data/nested/script.sh", Content: "echo"},
+		{Name: "data/payload.json", Content: "{}"},
+	}, test.Spec.Resources)
+
+	options = testCmdOptions{resources: []string{filepath.Join(dir, "data", "payload.json"), filepath.Join(dir, "extra", "*.json")}}
+	err = options.addTestResources(test, source, config.NewWithDefaults())
+	assert.EqualError(t, err, fmt.Sprintf("no resource found matching '%s'", filepath.Join(dir, "extra", "*.json")))
+}
@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/client"
+	"github.com/citrusframework/yaks/pkg/cmd/config"
+	"github.com/citrusframework/yaks/pkg/cmd/report"
+	"github.com/citrusframework/yaks/pkg/util/gherkin"
+	"github.com/citrusframework/yaks/pkg/util/kubernetes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FeaturesClasspath is the location of the feature files in the test runtime
+const FeaturesClasspath = "classpath:org/citrusframework/yaks/"
+
+// createAndRunScenarios splits the feature of the given source into groups of scenarios and runs each group as a test
+// of a parallel test suite. The results of all groups are merged into a single result of the feature. The given
+// scenarios restrict the run to these scenarios of the feature.
+func (o *testCmdOptions) createAndRunScenarios(c client.Client, rawName string, runConfig *config.RunConfig, scenarios []string) (*v1alpha1.Test, error) {
+	if len(scenarios) == 0 {
+		data, err := o.loadData(rawName)
+		if err != nil {
+			return nil, err
+		}
+
+		uri := FeaturesClasspath + kubernetes.SanitizeFileName(rawName)
+		for _, line := range gherkin.ScenarioLines(data) {
+			scenarios = append(scenarios, fmt.Sprintf("%s:%d", uri, line))
+		}
+
+		if len(scenarios) <= o.splitScenarios {
+			// nothing to split, run the feature as a whole
+			return o.createAndRunTest(c, rawName, runConfig, nil)
+		}
+	} else if len(scenarios) <= o.splitScenarios {
+		return o.createAndRunTest(c, rawName, runConfig, scenarios)
+	}
+
+	// all suite tests share the bundled test content, only the feature filter is kept inline
+	test, bundles, err := o.newTest(c, rawName, runConfig, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	suite := newSuite(test.Name, runConfig, v1alpha1.TestSuitePolicyParallel)
+	for i := 0; i < len(scenarios); i += o.splitScenarios {
+		end := i + o.splitScenarios
+		if end > len(scenarios) {
+			end = len(scenarios)
+		}
+
+		spec := test.Spec.DeepCopy()
+		setEnvValue(&spec.Env, CucumberFeatures, strings.Join(scenarios[i:end], ","))
+		suite.Spec.Tests = append(suite.Spec.Tests, v1alpha1.TestSuiteEntry{
+			Name: fmt.Sprintf("part-%d", len(suite.Spec.Tests)+1),
+			Spec: spec,
+		})
+	}
+	fmt.Printf("Running %d scenario(s) of test %s in %d parallel tests\n", len(scenarios), test.Name, len(suite.Spec.Tests))
+
+	status, err := o.runSuite(c, &suite, bundles, runConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeSuiteTests(test, suite, status), status.AsError()
+}
+
+// mergeSuiteTests merges the results of all tests in the suite into the result of the given feature test. The tests
+// run in parallel, so the duration of the feature is the duration of the slowest test.
+func mergeSuiteTests(test *v1alpha1.Test, suite v1alpha1.TestSuite, status v1alpha1.TestPhase) *v1alpha1.Test {
+	merged := v1alpha1.Test{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: test.Namespace,
+			Name:      test.Name,
+		},
+		Status: v1alpha1.TestStatus{
+			Phase: status,
+		},
+	}
+
+	var duration time.Duration
+	for _, result := range suite.Status.Tests {
+		fmt.Printf("Test %s %s\n", result.Name, string(result.Phase))
+
+		report.AppendTestResults(&merged.Status.Results, result.Results)
+		merged.Status.Errors = append(merged.Status.Errors, result.Errors...)
+		if d, err := time.ParseDuration(result.Results.Duration); err == nil && d > duration {
+			duration = d
+		}
+	}
+
+	if duration > 0 {
+		merged.Status.Results.Duration = duration.String()
+	}
+	if len(suite.Status.Tests) == 0 {
+		// the suite failed before any test has been started, e.g. because of an invalid spec
+		merged.Status.Errors = append(merged.Status.Errors, suite.Status.Errors...)
+	}
+
+	fmt.Printf("Test %s %s\n", test.Name, string(status))
+	return &merged
+}
@@ -0,0 +1,126 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/cmd/report"
+	"github.com/citrusframework/yaks/pkg/util/kubernetes"
+	"github.com/citrusframework/yaks/pkg/util/shard"
+)
+
+const (
+	// ShardByName distributes the tests to the shards by a hash of the test name
+	ShardByName = "name"
+	// ShardByDuration distributes the tests to the shards by the test durations recorded in the shard durations directory
+	ShardByDuration = "duration"
+)
+
+// selectShard discovers all tests of the given sources and selects the tests that belong to the shard of this run.
+// Every CI job runs the same command with a different shard index, so the selection must be deterministic.
+func (o *testCmdOptions) selectShard(sources []string) error {
+	s, err := shard.Parse(o.shard)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0)
+	for _, source := range discoverTests(sources) {
+		names = append(names, kubernetes.SanitizeName(source))
+	}
+
+	var selected []string
+	if o.shardBy == ShardByDuration && o.shardDurations != "" && isDir(o.shardDurations) {
+		selected = shard.ByDuration(names, loadDurations(o.shardDurations, names), s)
+	} else {
+		if o.shardBy == ShardByDuration {
+			fmt.Printf("No test durations found in '%s', distributing tests by name\n", o.shardDurations)
+		}
+		selected = shard.ByName(names, s)
+	}
+
+	o.shardTests = make(map[string]bool, len(selected))
+	for _, name := range selected {
+		o.shardTests[name] = true
+	}
+
+	fmt.Printf("Running shard %s with %d of %d tests\n", s, len(selected), len(names))
+	return nil
+}
+
+// inShard checks whether the test for the given source belongs to the shard of this run
+func (o *testCmdOptions) inShard(source string) bool {
+	return o.shardTests == nil || o.shardTests[kubernetes.SanitizeName(source)]
+}
+
+// anyInShard checks whether any test of the given sources belongs to the shard of this run
+func (o *testCmdOptions) anyInShard(sources []string) bool {
+	if o.shardTests == nil {
+		return true
+	}
+
+	for _, source := range discoverTests(sources) {
+		if o.inShard(source) {
+			return true
+		}
+	}
+	return false
+}
+
+// discoverTests returns the test files of the given sources, directories are searched recursively
+func discoverTests(sources []string) []string {
+	tests := make([]string, 0)
+	for _, source := range sources {
+		if isDir(source) {
+			files, err := ioutil.ReadDir(source)
+			if err != nil {
+				continue
+			}
+
+			nested := make([]string, 0, len(files))
+			for _, f := range files {
+				nested = append(nested, path.Join(source, f.Name()))
+			}
+			tests = append(tests, discoverTests(nested)...)
+		} else if strings.HasSuffix(source, FileSuffix) {
+			tests = append(tests, source)
+		}
+	}
+	return tests
+}
+
+// loadDurations reads the durations of the given tests from the results of a previous run in the given directory
+func loadDurations(dir string, names []string) map[string]time.Duration {
+	durations := make(map[string]time.Duration)
+	for _, name := range names {
+		results, err := report.LoadResultsFrom(dir, name)
+		if err != nil || results == nil || results.Duration == "" {
+			continue
+		}
+
+		if duration, err := time.ParseDuration(results.Duration); err == nil {
+			durations[name] = duration
+		}
+	}
+	return durations
+}
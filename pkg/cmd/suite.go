@@ -0,0 +1,234 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/client"
+	"github.com/citrusframework/yaks/pkg/cmd/config"
+	"github.com/citrusframework/yaks/pkg/cmd/report"
+	"github.com/citrusframework/yaks/pkg/controller/testsuite"
+	"github.com/citrusframework/yaks/pkg/install"
+	"github.com/citrusframework/yaks/pkg/util/kubernetes"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// createAndRunSuite runs the given test sources as a test suite. The operator executes the tests according to the
+// suite policy, the command streams the logs of all suite tests and collects the results once the suite is finished.
+func (o *testCmdOptions) createAndRunSuite(c client.Client, configSource string, sources []string, runConfig *config.RunConfig, results *v1alpha1.TestResults) error {
+	name := kubernetes.SanitizeName(configSource)
+	if name == "" {
+		return errors.New("unable to determine test suite name")
+	}
+
+	suite := newSuite(name, runConfig, v1alpha1.TestSuitePolicy(o.suitePolicy))
+	if suite.Spec.Policy == "" && (o.failFast || runConfig.Config.FailFast) {
+		suite.Spec.Policy = v1alpha1.TestSuitePolicyFailFast
+	}
+
+	allBundles := make([]*corev1.ConfigMap, 0)
+	for _, source := range sources {
+		// the suite embeds the spec of every test, so all test content goes into bundles and the embedded spec only
+		// holds the references and the run settings of the test
+		test, bundles, err := o.newTest(c, source, runConfig, 0)
+		if err != nil {
+			return err
+		}
+		allBundles = append(allBundles, bundles...)
+		suite.Spec.Tests = append(suite.Spec.Tests, v1alpha1.TestSuiteEntry{
+			Name: test.Name,
+			Spec: &test.Spec,
+		})
+	}
+
+	status, err := o.runSuite(c, &suite, allBundles, runConfig)
+	if err != nil {
+		return err
+	}
+
+	errorCount := len(results.Errors)
+	for _, result := range suite.Status.Tests {
+		fmt.Printf("Test %s %s\n", result.Name, string(result.Phase))
+
+		test := v1alpha1.Test{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: result.Name,
+			},
+			Status: v1alpha1.TestStatus{
+				Phase:   result.Phase,
+				Results: result.Results,
+				Errors:  result.Errors,
+			},
+		}
+		report.AppendTestResults(results, report.TestResultsFor(&test))
+
+		if saveErr := report.SaveTestResults(&test); saveErr != nil {
+			fmt.Printf("Failed to save test results: %s", saveErr.Error())
+		}
+	}
+	if len(suite.Status.Tests) == 0 {
+		// the suite failed before any test has been started, e.g. because of an invalid spec
+		results.Errors = append(results.Errors, suite.Status.Errors...)
+	}
+
+	fmt.Printf("Test suite %s\n", string(status))
+	if len(results.Errors) > errorCount {
+		// the test results already report why the suite has failed, so the generic phase error is not reported again
+		return nil
+	}
+	return status.AsError()
+}
+
+// newSuite creates a test suite with the given execution policy in the namespace of the run configuration
+func newSuite(name string, runConfig *config.RunConfig, policy v1alpha1.TestSuitePolicy) v1alpha1.TestSuite {
+	return v1alpha1.TestSuite{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha1.TestSuiteKind,
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: runConfig.Config.Namespace.Name,
+			Name:      name,
+		},
+		Spec: v1alpha1.TestSuiteSpec{
+			Policy: policy,
+		},
+	}
+}
+
+// runSuite creates or updates the given test suite, streams the logs of all suite tests and waits for the suite to
+// finish. The given resource bundles get owned by the suite.
+func (o *testCmdOptions) runSuite(c client.Client, suite *v1alpha1.TestSuite, bundles []*corev1.ConfigMap, runConfig *config.RunConfig) (v1alpha1.TestPhase, error) {
+	existed := false
+	err := c.Create(o.Context, suite)
+	if err != nil && k8serrors.IsAlreadyExists(err) {
+		existed = true
+		clone := suite.DeepCopy()
+		var key k8sclient.ObjectKey
+		key, err = k8sclient.ObjectKeyFromObject(clone)
+		if err != nil {
+			return "", err
+		}
+		err = c.Get(o.Context, key, clone)
+		if err != nil {
+			return "", err
+		}
+		suite.ResourceVersion = clone.ResourceVersion
+		err = c.Update(o.Context, suite)
+		if err != nil {
+			return "", err
+		}
+		// Reset status so that the suite runs again
+		suite.Status = v1alpha1.TestSuiteStatus{}
+		err = c.Status().Update(o.Context, suite)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := createResourceBundles(o.Context, c, ownerReferenceFor(suite), bundles); err != nil {
+		return "", err
+	}
+
+	if !existed {
+		fmt.Printf("test suite \"%s\" created\n", suite.Name)
+	} else {
+		fmt.Printf("test suite \"%s\" updated\n", suite.Name)
+	}
+
+	timeout, err := install.OperatorTestTimeout(o.Context, c, suite.Namespace)
+	if err != nil {
+		return "", err
+	}
+	tests, err := suiteTestsFor(o.Context, c, suite, timeout)
+	if err != nil {
+		return "", err
+	}
+	lookup := unfinishedTestsIn(o.Context, c, suite.Namespace, timeout)
+
+	ctx, cancel := context.WithCancel(o.Context)
+	var status v1alpha1.TestPhase = "Unknown"
+	waitErr := make(chan error, 1)
+	go func() {
+		waitErr <- kubernetes.WaitCondition(o.Context, c, suite, func(obj interface{}) (bool, error) {
+			if val, ok := obj.(*v1alpha1.TestSuite); ok {
+				if val.Status.Phase == v1alpha1.TestPhaseError ||
+					val.Status.Phase == v1alpha1.TestPhasePassed ||
+					val.Status.Phase == v1alpha1.TestPhaseFailed {
+					status = val.Status.Phase
+					return true, nil
+				}
+			}
+			return false, nil
+		}, suiteWaitTimeoutFor(suite, tests, lookup))
+
+		cancel()
+	}()
+
+	if err := o.printLogs(ctx, labels.Set{v1alpha1.TestSuiteLabel: suite.Name}, runConfig); err != nil {
+		return "", err
+	}
+
+	if err := <-waitErr; err != nil {
+		return status, errors.Wrap(err, fmt.Sprintf("failed to wait for test suite %s", suite.Name))
+	}
+
+	return status, nil
+}
+
+// suiteTestsFor returns the tests run by the given suite. Entries without embedded spec reference a test in the
+// namespace of the suite. Tests that do not exist are left empty as the operator fails the suite for them anyway.
+// Tests without timeout get the given default timeout of the operator.
+func suiteTestsFor(ctx context.Context, c client.Client, suite *v1alpha1.TestSuite, timeout string) ([]v1alpha1.Test, error) {
+	tests := make([]v1alpha1.Test, 0, len(suite.Spec.Tests))
+	for _, entry := range suite.Spec.Tests {
+		test := v1alpha1.Test{}
+		if entry.Spec != nil {
+			test.Spec = *entry.Spec
+		} else {
+			key := k8sclient.ObjectKey{Namespace: suite.Namespace, Name: testsuite.RefFor(entry)}
+			if err := c.Get(ctx, key, &test); err != nil && !k8serrors.IsNotFound(err) {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to get test %s of suite %s", key.Name, suite.Name))
+			}
+		}
+		if test.Spec.Timeout == "" {
+			test.Spec.Timeout = timeout
+		}
+		tests = append(tests, test)
+	}
+	return tests, nil
+}
+
+// isSuitePolicy checks whether the given policy is supported by test suites
+func isSuitePolicy(policy string) bool {
+	for _, p := range v1alpha1.TestSuitePolicies {
+		if string(p) == policy {
+			return true
+		}
+	}
+	return false
+}
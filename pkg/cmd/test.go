@@ -31,17 +31,17 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
 	"github.com/citrusframework/yaks/pkg/client"
 	"github.com/citrusframework/yaks/pkg/cmd/config"
 	"github.com/citrusframework/yaks/pkg/cmd/report"
+	"github.com/citrusframework/yaks/pkg/install"
+	"github.com/citrusframework/yaks/pkg/util/glob"
 	"github.com/citrusframework/yaks/pkg/util/kubernetes"
-	"github.com/citrusframework/yaks/pkg/util/openshift"
+	"github.com/citrusframework/yaks/pkg/util/shard"
 	"github.com/fatih/color"
 	"github.com/google/uuid"
-	projectv1 "github.com/openshift/api/project/v1"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/wercker/stern/stern"
@@ -72,7 +72,7 @@ func newCmdTest(rootCmdOptions *RootCmdOptions) *cobra.Command {
 
 	cmd := cobra.Command{
 		PersistentPreRunE: options.preRun,
-		Use:               "test [options] [test file to execute]",
+		Use:               "test [options] [test files, directories or glob patterns to execute]",
 		Short:             "Execute a test on Kubernetes",
 		Long:              `Deploys and execute a pod on Kubernetes for running tests.`,
 		PreRunE:           options.validateArgs,
@@ -81,14 +81,42 @@ func newCmdTest(rootCmdOptions *RootCmdOptions) *cobra.Command {
 	}
 
 	cmd.Flags().StringArrayVarP(&options.dependencies, "dependency", "d", nil, "Adds runtime dependencies that get automatically loaded before the test is executed.")
+	cmd.Flags().StringArrayVar(&options.repositories, "repository", nil, "Adds a Maven repository used to resolve runtime dependencies. E.g \"--repository my-repo=https://repo.example.com/maven2\"")
 	cmd.Flags().StringArrayVarP(&options.uploads, "upload", "u", nil, "Upload a given library to the cluster to allow it to be used by tests.")
 	cmd.Flags().StringVarP(&options.settings, "settings", "s", "", "Path to runtime settings file. File content is added to the test runtime and can hold runtime dependency information for instance.")
 	cmd.Flags().StringArrayVarP(&options.env, "env", "e", nil, "Set an environment variable in the integration container. E.g \"-e MY_VAR=my-value\"")
+	cmd.Flags().StringArrayVar(&options.envFromSecrets, "env-from-secret", nil, "Set environment variables from a secret. Either all keys \"--env-from-secret my-secret\" or a single key \"--env-from-secret MY_VAR=my-secret/my-key\"")
+	cmd.Flags().StringArrayVar(&options.envFromConfigMaps, "env-from-configmap", nil, "Set environment variables from a config map. Either all keys \"--env-from-configmap my-config\" or a single key \"--env-from-configmap MY_VAR=my-config/my-key\"")
+	cmd.Flags().StringArrayVar(&options.secrets, "secret", nil, "Mount a secret into the test container in the format name[:path], by default at /etc/yaks/secrets/<name>")
+	cmd.Flags().StringArrayVar(&options.configMaps, "configmap", nil, "Mount a config map into the test container in the format name[:path], by default at /etc/yaks/configmaps/<name>")
 	cmd.Flags().StringArrayVarP(&options.tags, "tag", "t", nil, "Specify a tag filter to only run tests that match given tag expression")
+	cmd.Flags().StringArrayVar(&options.resources, "resource", nil, "Resource file, directory or glob pattern to add to the test, e.g. payloads, scripts or additional feature files")
 	cmd.Flags().StringArrayVarP(&options.features, "feature", "f", nil, "Feature file to include in the test run")
 	cmd.Flags().StringArrayVarP(&options.glue, "glue", "g", nil, "Additional glue path to be added in the Cucumber runtime options")
 	cmd.Flags().StringVarP(&options.options, "options", "o", "", "Cucumber runtime options")
 	cmd.Flags().VarP(&options.report, "report", "r", "Create test report in given output format")
+	cmd.Flags().StringVar(&options.image, "image", "", "Container image used to run the tests, e.g. a custom runtime image with additional step libraries")
+	cmd.Flags().StringVar(&options.imagePullPolicy, "image-pull-policy", "", "Pull policy of the runtime image (Always, IfNotPresent or Never)")
+	cmd.Flags().StringArrayVar(&options.imagePullSecrets, "image-pull-secret", nil, "Name of a secret used to pull the runtime image")
+	cmd.Flags().StringArrayVar(&options.podRequests, "pod-request", nil, "Resource request of the test container. E.g \"--pod-request memory=512Mi\"")
+	cmd.Flags().StringArrayVar(&options.podLimits, "pod-limit", nil, "Resource limit of the test container. E.g \"--pod-limit cpu=1\"")
+	cmd.Flags().StringArrayVar(&options.nodeSelector, "node-selector", nil, "Node selector for the test pod. E.g \"--node-selector disktype=ssd\"")
+	cmd.Flags().StringArrayVar(&options.tolerations, "toleration", nil, "Toleration for the test pod in the format key[=value]:effect")
+	cmd.Flags().StringVar(&options.serviceAccount, "service-account", "", "Service account used to run the test pod")
+	cmd.Flags().StringVar(&options.timeout, "timeout", "", "Maximum duration of the test run, e.g. 1h (defaults to the operator setting)")
+	cmd.Flags().IntVar(&options.retries, "retries", 0, "Number of times a failed test is run again, scenarios passing on a retry are reported as flaky")
+	cmd.Flags().StringVar(&options.podSpec, "pod-spec", "", "Path to a file holding pod customizations such as affinity, security context, volumes and volume mounts")
+	cmd.Flags().StringVar(&options.mavenSettings, "maven-settings", "", "Maven settings.xml used to resolve runtime dependencies, in the format configmap:name[/key] or secret:name[/key]")
+	cmd.Flags().StringVar(&options.mavenMirror, "maven-mirror", "", "Maven repository mirroring all remote repositories, e.g. an internal Nexus")
+	cmd.Flags().StringVar(&options.mavenMirrorSecret, "maven-mirror-secret", "", "Name of a secret holding the Maven mirror credentials as keys username and password")
+	cmd.Flags().BoolVar(&options.suite, "suite", false, "Run the tests of a directory as a test suite that is executed by the operator")
+	cmd.Flags().StringVar(&options.suitePolicy, "suite-policy", "", "Execution policy of the test suite (Sequential, Parallel or FailFast)")
+	cmd.Flags().BoolVar(&options.failFast, "fail-fast", false, "Stop running tests after the first failed test, the remaining tests are reported as skipped")
+	cmd.Flags().IntVar(&options.splitScenarios, "split-scenarios", 0, "Split each feature into tests of the given number of scenarios that run in parallel")
+	cmd.Flags().StringVar(&options.shard, "shard", "", "Run only a slice of the tests in the format index/total, e.g. 2/5 runs the second of five shards")
+	cmd.Flags().StringVar(&options.shardBy, "shard-by", ShardByName, "How tests are distributed to the shards, either by test 'name' or by test 'duration' of the previous run")
+	cmd.Flags().StringVar(&options.shardDurations, "shard-durations", "", "Directory holding the test results of a previous run used to distribute the tests by duration, e.g. a merged _output directory")
+	cmd.Flags().BoolVar(&options.rerunFailed, "rerun-failed", false, "Run only the scenarios that failed in the previous run as recorded in the output directory")
 
 	return &cmd
 }
@@ -96,19 +124,106 @@ func newCmdTest(rootCmdOptions *RootCmdOptions) *cobra.Command {
 type testCmdOptions struct {
 	*RootCmdOptions
 	dependencies []string
+	repositories []string
 	uploads      []string
 	settings     string
 	env          []string
+	resources    []string
 	tags         []string
 	features     []string
 	glue         []string
 	options      string
 	report       report.OutputFormat
+
+	envFromSecrets    []string
+	envFromConfigMaps []string
+	secrets           []string
+	configMaps        []string
+
+	image            string
+	imagePullPolicy  string
+	imagePullSecrets []string
+
+	podRequests    []string
+	podLimits      []string
+	nodeSelector   []string
+	tolerations    []string
+	serviceAccount string
+	podSpec        string
+	timeout        string
+	retries        int
+
+	mavenSettings     string
+	mavenMirror       string
+	mavenMirrorSecret string
+
+	suite       bool
+	suitePolicy string
+
+	rerunFailed bool
+	failFast    bool
+
+	shard          string
+	shardBy        string
+	shardDurations string
+	shardTests     map[string]bool
+
+	splitScenarios int
+
+	// pool hands out the namespaces of the namespace pool to the test runs
+	pool *namespacePool
 }
 
 func (o *testCmdOptions) validateArgs(_ *cobra.Command, args []string) error {
-	if len(args) != 1 {
-		return errors.New(fmt.Sprintf("accepts exactly 1 test name to execute, received %d", len(args)))
+	if len(args) == 0 {
+		return errors.New("accepts at least 1 test name to execute, received 0")
+	}
+
+	if o.rerunFailed && o.suite {
+		return errors.New("option --rerun-failed cannot be combined with --suite")
+	}
+
+	if o.rerunFailed && o.features != nil {
+		return errors.New("option --rerun-failed cannot be combined with --feature")
+	}
+
+	if o.splitScenarios < 0 {
+		return errors.New(fmt.Sprintf("invalid number of scenarios per test %d, expected a positive number", o.splitScenarios))
+	}
+
+	if o.splitScenarios > 0 && o.suite {
+		return errors.New("option --split-scenarios cannot be combined with --suite")
+	}
+
+	if o.splitScenarios > 0 && o.features != nil {
+		return errors.New("option --split-scenarios cannot be combined with --feature")
+	}
+
+	if o.splitScenarios > 0 && hasEnvValue(o.env, CucumberFeatures) {
+		return errors.New(fmt.Sprintf("option --split-scenarios cannot be combined with environment variable %s", CucumberFeatures))
+	}
+
+	if o.shard != "" {
+		if _, err := shard.Parse(o.shard); err != nil {
+			return err
+		}
+	}
+
+	if o.shardBy != ShardByName && o.shardBy != ShardByDuration {
+		return errors.New(fmt.Sprintf("unsupported shard distribution '%s', expected one of [%s %s]", o.shardBy, ShardByName, ShardByDuration))
+	}
+
+	if o.retries < 0 {
+		return errors.New(fmt.Sprintf("invalid number of retries %d, expected a positive number", o.retries))
+	}
+
+	if o.suitePolicy != "" {
+		if !o.suite {
+			return errors.New("option --suite-policy requires --suite")
+		}
+		if !isSuitePolicy(o.suitePolicy) {
+			return errors.New(fmt.Sprintf("unsupported suite policy '%s', expected one of %v", o.suitePolicy, v1alpha1.TestSuitePolicies))
+		}
 	}
 
 	return nil
@@ -116,7 +231,6 @@ func (o *testCmdOptions) validateArgs(_ *cobra.Command, args []string) error {
 
 func (o *testCmdOptions) run(_ *cobra.Command, args []string) error {
 	var err error
-	source := args[0]
 
 	results := v1alpha1.TestResults{}
 	defer report.PrintSummaryReport(&results)
@@ -124,21 +238,62 @@ func (o *testCmdOptions) run(_ *cobra.Command, args []string) error {
 		defer report.GenerateReport(&results, o.report)
 	}
 
-	if isDir(source) {
-		err = o.runTestGroup(source, &results)
-		if err == nil && len(results.Errors) > 0 {
-			err = errors.New("There are test failures!")
+	var sources []string
+	if sources, err = resolveSources(args); err != nil {
+		return err
+	}
+
+	if o.shard != "" {
+		if err = o.selectShard(sources); err != nil {
+			return err
 		}
-	} else {
-		err = o.runTest(source, &results)
+	}
+
+	if len(sources) == 1 && !isDir(sources[0]) {
+		if !o.inShard(sources[0]) {
+			return nil
+		}
+		return o.runTest(sources[0], &results)
+	}
+
+	groups := groupSources(sources)
+	for _, group := range groups {
+		if len(results.Errors) > 0 {
+			runConfig, err := o.getRunConfig(group[0])
+			if err != nil {
+				results.Errors = append(results.Errors, v1alpha1.TestError{Message: err.Error()})
+				continue
+			}
+			if o.failFast || runConfig.Config.FailFast {
+				o.skipTests(group, runConfig.Config.Recursive, &results)
+				continue
+			}
+		}
+
+		var groupErr error
+		if isDir(group[0]) {
+			groupErr = o.runTestGroup(group[0], &results)
+		} else {
+			groupErr = o.runTests(group[0], group, &results)
+		}
+
+		if groupErr != nil && len(groups) == 1 {
+			return groupErr
+		} else if groupErr != nil {
+			results.Errors = append(results.Errors, v1alpha1.TestError{Message: groupErr.Error()})
+		}
+	}
+
+	if len(results.Errors) > 0 {
+		err = errors.New("There are test failures!")
 	}
 
 	return err
 }
 
-func (o *testCmdOptions) runTest(source string, results *v1alpha1.TestResults) error {
-	c, err := o.GetCmdClient()
-	if err != nil {
+func (o *testCmdOptions) runTest(source string, results *v1alpha1.TestResults) (err error) {
+	var c client.Client
+	if c, err = o.GetCmdClient(); err != nil {
 		return err
 	}
 
@@ -149,11 +304,14 @@ func (o *testCmdOptions) runTest(source string, results *v1alpha1.TestResults) e
 
 	testNamespace := runConfig.Config.Namespace.Name
 	if runConfig.Config.Namespace.Temporary {
-		if namespace, err := o.createTempNamespace(runConfig, c); err != nil {
+		var namespace metav1.Object
+		if namespace, err = o.createTempNamespace(runConfig, c); err != nil {
 			return err
-		} else if namespace != nil && runConfig.Config.Namespace.AutoRemove {
+		} else if namespace != nil {
 			testNamespace = namespace.GetName()
-			defer deleteTempNamespace(namespace, c, o.Context)
+			defer func() {
+				o.releaseTempNamespace(namespace, runConfig, c, err == nil)
+			}()
 		}
 	}
 
@@ -167,36 +325,53 @@ func (o *testCmdOptions) runTest(source string, results *v1alpha1.TestResults) e
 		return err
 	}
 
-	var test *v1alpha1.Test
-	test, err = o.createAndRunTest(c, source, runConfig)
-	if test != nil {
-		report.AppendTestResults(results, test.Status.Results)
+	return o.runAndReportTest(c, source, runConfig, results)
+}
 
-		if saveErr := report.SaveTestResults(test); saveErr != nil {
-			fmt.Printf("Failed to save test results: %s", saveErr.Error())
-		}
+func (o *testCmdOptions) runTestGroup(source string, results *v1alpha1.TestResults) error {
+	var err error
+	var files []os.FileInfo
+	if files, err = ioutil.ReadDir(source); err != nil {
+		return err
 	}
-	return err
+
+	sources := make([]string, 0, len(files))
+	for _, f := range files {
+		sources = append(sources, path.Join(source, f.Name()))
+	}
+
+	return o.runTests(source, sources, results)
 }
 
-func (o *testCmdOptions) runTestGroup(source string, results *v1alpha1.TestResults) error {
-	c, err := o.GetCmdClient()
-	if err != nil {
+// runTests executes the given test sources as a group that shares the run configuration
+// found for the given config source. Directories are run as nested test groups.
+func (o *testCmdOptions) runTests(configSource string, sources []string, results *v1alpha1.TestResults) (err error) {
+	if !o.anyInShard(sources) {
+		// none of the tests belongs to the shard of this run, skip the group setup as well
+		return nil
+	}
+
+	var c client.Client
+	if c, err = o.GetCmdClient(); err != nil {
 		return err
 	}
 
 	var runConfig *config.RunConfig
-	if runConfig, err = o.getRunConfig(source); err != nil {
+	if runConfig, err = o.getRunConfig(configSource); err != nil {
 		return err
 	}
 
+	suiteErrors := make(v1alpha1.TestErrors, 0)
 	var testNamespace = runConfig.Config.Namespace.Name
 	if runConfig.Config.Namespace.Temporary {
-		if namespace, err := o.createTempNamespace(runConfig, c); err != nil {
+		var namespace metav1.Object
+		if namespace, err = o.createTempNamespace(runConfig, c); err != nil {
 			return err
-		} else if namespace != nil && runConfig.Config.Namespace.AutoRemove {
+		} else if namespace != nil {
 			testNamespace = namespace.GetName()
-			defer deleteTempNamespace(namespace, c, o.Context)
+			defer func() {
+				o.releaseTempNamespace(namespace, runConfig, c, err == nil && len(suiteErrors) == 0)
+			}()
 		}
 	}
 
@@ -204,43 +379,48 @@ func (o *testCmdOptions) runTestGroup(source string, results *v1alpha1.TestResul
 		return err
 	}
 
-	var files []os.FileInfo
-	if files, err = ioutil.ReadDir(source); err != nil {
-		return err
-	}
-
-	baseDir := getBaseDir(source)
+	baseDir := getBaseDir(configSource)
 	defer runSteps(runConfig.Post, testNamespace, baseDir)
 	if err = runSteps(runConfig.Pre, testNamespace, baseDir); err != nil {
 		return err
 	}
 
-	suiteErrors := make([]string, 0)
-	for _, f := range files {
-		name := path.Join(source, f.Name())
-		if f.IsDir() && runConfig.Config.Recursive {
+	failFast := o.failFast || runConfig.Config.FailFast
+	errorCount := len(results.Errors)
+	suiteSources := make([]string, 0)
+	for i, name := range sources {
+		if failFast && !o.suite && (len(suiteErrors) > 0 || len(results.Errors) > errorCount) {
+			o.skipTests(sources[i:], runConfig.Config.Recursive, results)
+			break
+		}
+
+		if isDir(name) {
+			if !runConfig.Config.Recursive {
+				continue
+			}
+
 			groupError := o.runTestGroup(name, results)
 			if groupError != nil {
-				suiteErrors = append(suiteErrors, groupError.Error())
+				suiteErrors = append(suiteErrors, v1alpha1.TestError{Message: groupError.Error()})
 			}
-		} else if strings.HasSuffix(f.Name(), FileSuffix) {
-			var test *v1alpha1.Test
-			var testError error
-			test, testError = o.createAndRunTest(c, name, runConfig)
-			if test != nil {
-				report.AppendTestResults(results, test.Status.Results)
-
-				if saveErr := report.SaveTestResults(test); saveErr != nil {
-					fmt.Printf("Failed to save test results: %s", saveErr.Error())
-				}
+		} else if strings.HasSuffix(name, FileSuffix) && o.suite {
+			if !o.inShard(name) {
+				continue
 			}
-
-			if testError != nil {
-				suiteErrors = append(suiteErrors, testError.Error())
+			suiteSources = append(suiteSources, name)
+		} else if strings.HasSuffix(name, FileSuffix) {
+			if testError := o.runAndReportTest(c, name, runConfig, results); testError != nil {
+				suiteErrors = append(suiteErrors, v1alpha1.TestError{Test: name, Message: testError.Error()})
 			}
 		}
 	}
 
+	if len(suiteSources) > 0 {
+		if suiteError := o.createAndRunSuite(c, configSource, suiteSources, runConfig, results); suiteError != nil {
+			suiteErrors = append(suiteErrors, v1alpha1.TestError{Test: configSource, Message: suiteError.Error()})
+		}
+	}
+
 	if len(suiteErrors) > 0 {
 		results.Errors = append(results.Errors, suiteErrors...)
 	}
@@ -248,6 +428,75 @@ func (o *testCmdOptions) runTestGroup(source string, results *v1alpha1.TestResul
 	return nil
 }
 
+// resolveSources expands glob patterns in the given test arguments. Resolved sources keep the order of the arguments,
+// duplicates are removed.
+func resolveSources(args []string) ([]string, error) {
+	sources := make([]string, 0, len(args))
+	known := make(map[string]bool)
+
+	for _, arg := range args {
+		matches := []string{arg}
+		if !isRemoteFile(arg) && glob.HasMeta(arg) {
+			files, err := glob.Glob(arg)
+			if err != nil {
+				return nil, err
+			}
+
+			// patterns select feature files only, no matter if they use "**" or not
+			matches = make([]string, 0, len(files))
+			for _, file := range files {
+				if strings.HasSuffix(file, FileSuffix) {
+					matches = append(matches, file)
+				}
+			}
+			if len(matches) == 0 {
+				return nil, errors.New(fmt.Sprintf("no test found matching '%s'", arg))
+			}
+		}
+
+		for _, match := range matches {
+			if !known[match] {
+				known[match] = true
+				sources = append(sources, match)
+			}
+		}
+	}
+
+	return sources, nil
+}
+
+// groupSources groups the given test sources by their base directory so that tests living in the same directory
+// share the same run configuration. Directories always form a group on their own. Remote sources use the default run
+// configuration, so they share a group that never includes local tests.
+func groupSources(sources []string) [][]string {
+	// base directories of local files are empty or end with a separator, so the key never matches a base directory
+	const remoteGroup = "remote"
+
+	groups := make([][]string, 0)
+	index := make(map[string]int)
+
+	for _, source := range sources {
+		if isDir(source) {
+			groups = append(groups, []string{source})
+			continue
+		}
+
+		key := getBaseDir(source)
+		if isRemoteFile(source) {
+			key = remoteGroup
+		}
+
+		if i, ok := index[key]; ok {
+			groups[i] = append(groups[i], source)
+		} else {
+			index[key] = len(groups)
+			groups = append(groups, []string{source})
+		}
+	}
+
+	return groups
+}
+
 func getBaseDir(source string) string {
 	if isRemoteFile(source) {
 		return ""
@@ -283,6 +532,8 @@ func (o *testCmdOptions) getRunConfig(source string) (*config.RunConfig, error)
 		return nil, err
 	}
 
+	runConfig.BaseDir = getBaseDir(source)
+
 	if runConfig.Config.Namespace.Name == "" && !runConfig.Config.Namespace.Temporary {
 		runConfig.Config.Namespace.Name = o.Namespace
 	}
@@ -291,8 +542,45 @@ func (o *testCmdOptions) getRunConfig(source string) (*config.RunConfig, error)
 }
 
 func (o *testCmdOptions) createTempNamespace(runConfig *config.RunConfig, c client.Client) (metav1.Object, error) {
+	namespaceConfig := runConfig.Config.Namespace
+	limits, err := newNamespaceLimits(namespaceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if namespaceConfig.Pool.Size == 0 {
+		// the pool may be configured on the operator instead
+		if namespaceConfig.Pool.Size, err = install.OperatorNamespacePoolSize(o.Context, c, o.Namespace); err != nil {
+			return nil, err
+		}
+		runConfig.Config.Namespace.Pool.Size = namespaceConfig.Pool.Size
+	}
+
+	if namespaceConfig.Pool.Size > 0 {
+		if o.pool == nil {
+			o.pool = newNamespacePool(o.RootCmdOptions, c)
+		}
+		namespace, err := o.pool.acquire(namespaceConfig.Pool.Size, tempNamespaceLabels(namespaceConfig))
+		if err != nil || namespace == nil {
+			return namespace, err
+		}
+		runConfig.Config.Namespace.Name = namespace.GetName()
+
+		if len(namespaceConfig.Annotations) > 0 {
+			if err := setNamespaceMetadata(o.Context, c, namespace.GetName(), nil, namespaceConfig.Annotations); err != nil {
+				return namespace, err
+			}
+		}
+
+		if err := o.copyTestMavenResources(c, runConfig); err != nil {
+			return namespace, err
+		}
+
+		return namespace, applyNamespaceLimits(o.Context, c, namespace.GetName(), limits)
+	}
+
 	namespaceName := "yaks-" + uuid.New().String()
-	namespace, err := initializeTempNamespace(namespaceName, c, o.Context)
+	namespace, err := initializeTempNamespace(namespaceName, tempNamespaceLabels(namespaceConfig), namespaceConfig.Annotations, c, o.Context)
 	if err != nil {
 		return nil, err
 	}
@@ -302,64 +590,144 @@ func (o *testCmdOptions) createTempNamespace(runConfig *config.RunConfig, c clie
 		return namespace, err
 	}
 
-	if err := setupOperator(o.RootCmdOptions, namespaceName); err != nil {
+	operatorConfig, err := tempOperatorConfiguration(o.RootCmdOptions, c, namespaceName)
+	if err != nil {
+		return namespace, err
+	}
+	if err := setupOperator(o.RootCmdOptions, operatorConfig); err != nil {
+		return namespace, err
+	}
+
+	if err := o.copyTestMavenResources(c, runConfig); err != nil {
 		return namespace, err
 	}
 
-	return namespace, nil
+	return namespace, applyNamespaceLimits(o.Context, c, namespaceName, limits)
 }
 
-func (o *testCmdOptions) createAndRunTest(c client.Client, rawName string, runConfig *config.RunConfig) (*v1alpha1.Test, error) {
-	namespace := runConfig.Config.Namespace.Name
-	fileName := kubernetes.SanitizeFileName(rawName)
-	name := kubernetes.SanitizeName(rawName)
+// copyTestMavenResources copies the config maps and secrets referenced by the Maven settings of the tests from the
+// current namespace to the temporary namespace
+func (o *testCmdOptions) copyTestMavenResources(c client.Client, runConfig *config.RunConfig) error {
+	spec, err := o.newMavenSpec(runConfig)
+	if err != nil {
+		return err
+	}
+	return copyMavenResources(o.Context, c, o.Namespace, runConfig.Config.Namespace.Name, spec)
+}
 
-	if name == "" {
-		return nil, errors.New("unable to determine test name")
+// releaseTempNamespace either removes the temporary namespace or hands it back to the namespace pool. Depending on the
+// auto remove policy the namespace is kept for inspection when the test run has failed.
+func (o *testCmdOptions) releaseTempNamespace(namespace metav1.Object, runConfig *config.RunConfig, c client.Client, success bool) {
+	remove := runConfig.Config.Namespace.AutoRemove.Remove(success)
+	if runConfig.Config.Namespace.Pool.Size > 0 && o.pool != nil {
+		o.pool.release(namespace.GetName(), remove)
+	} else if remove {
+		if err := deleteTempNamespace(namespace, c, o.Context); err != nil {
+			fmt.Fprintf(os.Stderr, "WARN: %v\n", err)
+		}
+	} else {
+		fmt.Printf("Keeping namespace %s\n", namespace.GetName())
 	}
+}
 
-	data, err := o.loadData(rawName)
-	if err != nil {
-		return nil, err
+// skipTests reports the given test sources as skipped, e.g. because a previous test has failed in fail fast mode.
+// Directories are resolved to the tests they contain.
+func (o *testCmdOptions) skipTests(sources []string, recursive bool, results *v1alpha1.TestResults) {
+	for _, source := range sources {
+		if isDir(source) {
+			if !recursive {
+				continue
+			}
+
+			files, err := ioutil.ReadDir(source)
+			if err != nil {
+				continue
+			}
+			nested := make([]string, 0, len(files))
+			for _, f := range files {
+				nested = append(nested, path.Join(source, f.Name()))
+			}
+			o.skipTests(nested, recursive, results)
+		} else if strings.HasSuffix(source, FileSuffix) && o.inShard(source) {
+			fmt.Printf("Test %s skipped\n", source)
+			skipped := report.SkippedTestResults(source)
+			report.AppendTestResults(results, skipped)
+
+			if saveErr := report.SaveResults(kubernetes.SanitizeName(source), skipped); saveErr != nil {
+				fmt.Printf("Failed to save test results: %s", saveErr.Error())
+			}
+		}
 	}
+}
 
-	test := v1alpha1.Test{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       v1alpha1.TestKind,
-			APIVersion: v1alpha1.SchemeGroupVersion.String(),
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: namespace,
-			Name:      name,
-		},
-		Spec: v1alpha1.TestSpec{
-			Source: v1alpha1.SourceSpec{
-				Name:     fileName,
-				Content:  data,
-				Language: v1alpha1.LanguageGherkin,
-			},
-		},
+// runAndReportTest runs the test for the given source and saves its results in the output directory. When rerunning
+// failed tests only the scenarios that failed in the previous run are executed and their results are merged into the
+// previous results.
+func (o *testCmdOptions) runAndReportTest(c client.Client, source string, runConfig *config.RunConfig, results *v1alpha1.TestResults) error {
+	if !o.inShard(source) {
+		return nil
 	}
 
-	settings, err := o.newSettings()
+	var err error
+	var previous *v1alpha1.TestResults
+	var scenarios []string
+	if o.rerunFailed {
+		if previous, err = report.LoadResults(kubernetes.SanitizeName(source)); err != nil {
+			return err
+		}
 
-	if err != nil {
-		return nil, err
-	} else if settings != nil {
-		test.Spec.Settings = *settings
-	} else if len(o.dependencies) > 0 {
-		test.Spec.Settings = v1alpha1.SettingsSpec{
-			Name:    "",
-			Content: strings.Join(o.dependencies, ","),
+		if previous != nil {
+			var failed bool
+			if scenarios, failed = report.FailedScenarios(*previous); len(scenarios) == 0 && !failed {
+				fmt.Printf("Test %s passed in the previous run, skipping\n", source)
+				report.AppendTestResults(results, *previous)
+				return nil
+			}
 		}
 	}
 
-	if err := o.setupEnvSettings(&test, runConfig); err != nil {
+	var test *v1alpha1.Test
+	if o.splitScenarios > 0 {
+		test, err = o.createAndRunScenarios(c, source, runConfig, scenarios)
+	} else {
+		test, err = o.createAndRunTest(c, source, runConfig, scenarios)
+	}
+	if test == nil {
+		return err
+	}
+
+	testResults := report.TestResultsFor(test)
+	if previous != nil {
+		testResults = report.MergeTestResults(*previous, testResults, len(scenarios) > 0)
+	}
+	report.AppendTestResults(results, testResults)
+
+	if saveErr := report.SaveResults(test.Name, testResults); saveErr != nil {
+		fmt.Printf("Failed to save test results: %s", saveErr.Error())
+	}
+
+	if len(testResults.Errors) > 0 && (test.Status.Phase == v1alpha1.TestPhaseFailed || test.Status.Phase == v1alpha1.TestPhaseError) {
+		// the test results already report why the test has failed, so the generic phase error is not reported again
+		return nil
+	}
+	return err
+}
+
+// createAndRunTest creates the test for the given source and waits for it to finish. The given scenarios restrict the
+// test run to these scenarios of the feature.
+func (o *testCmdOptions) createAndRunTest(c client.Client, rawName string, runConfig *config.RunConfig, scenarios []string) (*v1alpha1.Test, error) {
+	test, bundles, err := o.newTest(c, rawName, runConfig, maxInlineTestSize)
+	if err != nil {
 		return nil, err
 	}
+	if len(scenarios) > 0 {
+		setEnvValue(&test.Spec.Env, CucumberFeatures, strings.Join(scenarios, ","))
+		fmt.Printf("Rerunning %d failed scenario(s) of test %s\n", len(scenarios), test.Name)
+	}
+	name := test.Name
 
 	existed := false
-	err = c.Create(o.Context, &test)
+	err = c.Create(o.Context, test)
 	if err != nil && k8serrors.IsAlreadyExists(err) {
 		existed = true
 		clone := test.DeepCopy()
@@ -373,50 +741,150 @@ func (o *testCmdOptions) createAndRunTest(c client.Client, rawName string, runCo
 			return nil, err
 		}
 		test.ResourceVersion = clone.ResourceVersion
-		err = c.Update(o.Context, &test)
+		err = c.Update(o.Context, test)
 		if err != nil {
 			return nil, err
 		}
 		// Reset status as well
 		test.Status = v1alpha1.TestStatus{}
-		err = c.Status().Update(o.Context, &test)
+		err = c.Status().Update(o.Context, test)
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := createResourceBundles(o.Context, c, ownerReferenceFor(test), bundles); err != nil {
+		return nil, err
+	}
+
 	if !existed {
 		fmt.Printf("test \"%s\" created\n", name)
 	} else {
 		fmt.Printf("test \"%s\" updated\n", name)
 	}
 
+	timeout, err := install.OperatorTestTimeout(o.Context, c, test.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	lookup := unfinishedTestsIn(o.Context, c, test.Namespace, timeout)
+
 	ctx, cancel := context.WithCancel(o.Context)
 	var status v1alpha1.TestPhase = "Unknown"
+	waitErr := make(chan error, 1)
 	go func() {
-		err = kubernetes.WaitCondition(o.Context, c, &test, func(obj interface{}) (bool, error) {
+		waitErr <- kubernetes.WaitCondition(o.Context, c, test, func(obj interface{}) (bool, error) {
 			if val, ok := obj.(*v1alpha1.Test); ok {
 				if val.Status.Phase == v1alpha1.TestPhaseDeleting ||
 					val.Status.Phase == v1alpha1.TestPhaseError ||
 					val.Status.Phase == v1alpha1.TestPhasePassed ||
-					val.Status.Phase == v1alpha1.TestPhaseFailed {
+					val.Status.Phase == v1alpha1.TestPhaseFailed ||
+					val.Status.Phase == v1alpha1.TestPhaseSkipped {
 					status = val.Status.Phase
 					return true, nil
 				}
 			}
 			return false, nil
-		}, 10*time.Minute)
+		}, waitTimeoutFor(test, lookup))
 
 		cancel()
 	}()
 
-	if err := o.printLogs(ctx, name, runConfig); err != nil {
+	if err := o.printLogs(ctx, labels.Set{"org.citrusframework.yaks/test": name}, runConfig); err != nil {
 		return nil, err
 	}
 
+	if err := <-waitErr; err != nil {
+		return test, errors.Wrap(err, fmt.Sprintf("failed to wait for test %s", name))
+	}
+
 	fmt.Printf("Test %s\n", string(status))
-	return &test, status.AsError()
+	return test, status.AsError()
+}
+
+// newTest builds the test for the given source. Test content exceeding the given inline size is moved into resource
+// bundles. The caller is responsible to create the returned resource bundles once the test, or the suite holding the
+// test, has been created.
+func (o *testCmdOptions) newTest(c client.Client, rawName string, runConfig *config.RunConfig, maxInline int) (*v1alpha1.Test, []*corev1.ConfigMap, error) {
+	namespace := runConfig.Config.Namespace.Name
+	fileName := kubernetes.SanitizeFileName(rawName)
+	name := kubernetes.SanitizeName(rawName)
+
+	if name == "" {
+		return nil, nil, errors.New("unable to determine test name")
+	}
+
+	data, err := o.loadData(rawName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	test := v1alpha1.Test{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha1.TestKind,
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: v1alpha1.TestSpec{
+			Source: v1alpha1.SourceSpec{
+				Name:     fileName,
+				Content:  data,
+				Language: v1alpha1.LanguageGherkin,
+			},
+		},
+	}
+
+	settings, err := o.newSettings()
+
+	if err != nil {
+		return nil, nil, err
+	} else if settings != nil {
+		test.Spec.Settings = *settings
+	}
+
+	if err := o.setupDependencies(&test, runConfig); err != nil {
+		return nil, nil, err
+	}
+
+	if err := o.setupEnvSettings(&test, runConfig); err != nil {
+		return nil, nil, err
+	}
+
+	if err := o.setupMounts(&test, runConfig); err != nil {
+		return nil, nil, err
+	}
+
+	if err := o.addTestResources(&test, rawName, runConfig); err != nil {
+		return nil, nil, err
+	}
+
+	bundles, err := bundleTestResources(&test, maxInline)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if test.Spec.Runtime, err = o.newRuntimeSpec(runConfig); err != nil {
+		return nil, nil, err
+	}
+
+	if o.timeout != "" {
+		if _, err := time.ParseDuration(o.timeout); err != nil {
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("invalid test timeout '%s'", o.timeout))
+		}
+		test.Spec.Timeout = o.timeout
+	} else {
+		// set the timeout explicitly so that the test runs and the command waits with the timeout of the operator
+		if test.Spec.Timeout, err = install.OperatorTestTimeout(o.Context, c, namespace); err != nil {
+			return nil, nil, err
+		}
+	}
+	test.Spec.Retries = o.retries
+
+	return &test, bundles, nil
 }
 
 func (o *testCmdOptions) uploadArtifacts(runConfig *config.RunConfig) error {
@@ -455,27 +923,21 @@ func (o *testCmdOptions) setupEnvSettings(test *v1alpha1.Test, runConfig *config
 		env = append(env, CucumberOptions+"="+runConfig.Config.Runtime.Cucumber.Options)
 	}
 
-	if o.env != nil {
-		copy(env, o.env)
+	for _, value := range o.env {
+		if pair := strings.SplitN(value, "=", 2); len(pair) != 2 || strings.TrimSpace(pair[0]) == "" {
+			return errors.New(fmt.Sprintf("malformed environment variable '%s', expected KEY=VALUE", value))
+		}
 	}
+	env = append(env, o.env...)
 
 	if len(env) > 0 {
 		test.Spec.Env = env
 	}
 
-	return nil
+	return o.setupEnvSources(test, runConfig)
 }
 
 func (o *testCmdOptions) newSettings() (*v1alpha1.SettingsSpec, error) {
-	runtimeDependencies := o.dependencies
-
-	if len(runtimeDependencies) > 0 {
-		settings := v1alpha1.SettingsSpec{
-			Content: strings.Join(runtimeDependencies, ","),
-		}
-		return &settings, nil
-	}
-
 	if o.settings == "" {
 		return nil, nil
 	}
@@ -496,7 +958,7 @@ func (o *testCmdOptions) newSettings() (*v1alpha1.SettingsSpec, error) {
 	return &settings, nil
 }
 
-func (o *testCmdOptions) printLogs(ctx context.Context, name string, runConfig *config.RunConfig) error {
+func (o *testCmdOptions) printLogs(ctx context.Context, selector labels.Set, runConfig *config.RunConfig) error {
 	t := "{{color .PodColor .PodName}} {{color .ContainerColor .ContainerName}} {{.Message}}"
 	funs := map[string]interface{}{
 		"color": func(color color.Color, text string) string {
@@ -515,7 +977,7 @@ func (o *testCmdOptions) printLogs(ctx context.Context, name string, runConfig *
 		KubeConfig: client.GetValidKubeConfig(o.KubeConfig),
 		//TailLines: &tail,
 		ContainerQuery: regexp.MustCompile(".*"),
-		LabelSelector:  labels.SelectorFromSet(labels.Set{"org.citrusframework.yaks/test": name}),
+		LabelSelector:  labels.SelectorFromSet(selector),
 		//LabelSelector: labels.SelectorFromSet(labels.Set{"name": "yaks"}),
 		ContainerState: stern.ContainerState(stern.RUNNING),
 		Since:          172800000000000,
@@ -624,64 +1086,6 @@ func runScript(scriptFile, desc, namespace, baseDir, timeout string) error {
 	return nil
 }
 
-func initializeTempNamespace(name string, c client.Client, context context.Context) (metav1.Object, error) {
-	var obj runtime.Object
-
-	if oc, err := openshift.IsOpenShift(c); err != nil {
-		panic(err)
-	} else if oc {
-		scheme := c.GetScheme()
-		projectv1.AddToScheme(scheme)
-
-		obj = &projectv1.ProjectRequest{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: projectv1.GroupVersion.String(),
-				Kind:       "ProjectRequest",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name: name,
-			},
-		}
-	} else {
-		obj = &corev1.Namespace{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: "v1",
-				Kind:       "Namespace",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name: name,
-			},
-		}
-	}
-	fmt.Printf("Creating new test namespace %s\n", name)
-	err := c.Create(context, obj)
-	return obj.(metav1.Object), err
-}
-
-func deleteTempNamespace(ns metav1.Object, c client.Client, context context.Context) {
-	if oc, err := openshift.IsOpenShift(c); err != nil {
-		panic(err)
-	} else if oc {
-		prj := &projectv1.Project{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: projectv1.GroupVersion.String(),
-				Kind:       "Project",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name: ns.GetName(),
-			},
-		}
-		if err = c.Delete(context, prj); err != nil {
-			fmt.Fprintf(os.Stderr, "WARN: Failed to AutoRemove namespace %s\n", ns.GetName())
-		}
-	} else {
-		if err = c.Delete(context, ns.(runtime.Object)); err != nil {
-			fmt.Fprintf(os.Stderr, "WARN: Failed to AutoRemove namespace %s\n", ns.GetName())
-		}
-	}
-	fmt.Printf("AutoRemove namespace %s\n", ns.GetName())
-}
-
 func (*testCmdOptions) loadData(fileName string) (string, error) {
 	var content []byte
 	var err error
@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupSources(t *testing.T) {
+	groups := groupSources([]string{
+		"a.feature",
+		"https://example.com/tests/remote.feature",
+		"suites/b.feature",
+		"c.feature",
+		"http://example.com/other.feature",
+		"suites/d.feature",
+	})
+
+	assert.Equal(t, [][]string{
+		{"a.feature", "c.feature"},
+		{"https://example.com/tests/remote.feature", "http://example.com/other.feature"},
+		{"suites/b.feature", "suites/d.feature"},
+	}, groups)
+}
+
+func TestResolveSources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yaks-sources-*")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	for _, name := range []string{"a.feature", "yaks-config.yaml", "sub/b.feature"} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte{}, 0644))
+	}
+
+	sources, err := resolveSources([]string{filepath.Join(dir, "*")})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.feature")}, sources)
+
+	sources, err = resolveSources([]string{filepath.Join(dir, "**")})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.feature"), filepath.Join(dir, "sub", "b.feature")}, sources)
+
+	_, err = resolveSources([]string{filepath.Join(dir, "*.yaml")})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/client"
+	testctrl "github.com/citrusframework/yaks/pkg/controller/test"
+	"github.com/citrusframework/yaks/pkg/controller/testsuite"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// waitMargin is added to the time the CLI waits for a test in order to cover scheduling the test pod, pulling the
+// runtime image and the operator picking up the test
+const waitMargin = 5 * time.Minute
+
+// testLookup returns the unfinished test with the given name or nil
+type testLookup func(name string) *v1alpha1.Test
+
+// unfinishedTestsIn looks up unfinished tests in the given namespace. Tests without timeout get the given default
+// timeout, missing tests are ignored.
+func unfinishedTestsIn(ctx context.Context, c client.Client, namespace string, timeout string) testLookup {
+	return func(name string) *v1alpha1.Test {
+		test := v1alpha1.Test{}
+		if err := c.Get(ctx, k8sclient.ObjectKey{Namespace: namespace, Name: name}, &test); err != nil {
+			return nil
+		}
+		if testsuite.IsFinished(test.Status.Phase) {
+			return nil
+		}
+		if test.Spec.Timeout == "" {
+			test.Spec.Timeout = timeout
+		}
+		return &test
+	}
+}
+
+// dependenciesDurationFor returns how long the given test may wait for the unfinished tests it depends on. The
+// dependencies run in parallel, so this is the time of the slowest dependency including its own dependencies.
+func dependenciesDurationFor(test *v1alpha1.Test, lookup testLookup) time.Duration {
+	return dependenciesDuration(test, lookup, map[string]bool{})
+}
+
+func dependenciesDuration(test *v1alpha1.Test, lookup testLookup, visited map[string]bool) time.Duration {
+	var duration time.Duration
+	for _, name := range test.Spec.DependsOn {
+		if visited[name] {
+			// dependency cycles are rejected by the operator
+			continue
+		}
+		visited[name] = true
+
+		if dependency := lookup(name); dependency != nil {
+			if d := dependenciesDuration(dependency, lookup, visited) + testDurationFor(dependency); d > duration {
+				duration = d
+			}
+		}
+		delete(visited, name)
+	}
+	return duration
+}
+
+// testDurationFor returns the maximum time the given test may take including all retries and the backoff between them.
+// The timeout of the test is expected to be set, the command never applies its own default timeout.
+func testDurationFor(test *v1alpha1.Test) time.Duration {
+	timeout, err := testctrl.TimeoutFor(test)
+	if err != nil {
+		// the operator rejects the test, it finishes right away
+		return 0
+	}
+
+	duration := time.Duration(1+test.Spec.Retries) * timeout
+	for retry := 1; retry <= test.Spec.Retries; retry++ {
+		if backoff, err := testctrl.RetryBackoffFor(test, retry); err == nil {
+			duration += backoff
+		}
+	}
+	return duration
+}
+
+// waitTimeoutFor returns how long the CLI waits for the given test to finish including the time the test waits for
+// its dependencies
+func waitTimeoutFor(test *v1alpha1.Test, lookup testLookup) time.Duration {
+	return dependenciesDurationFor(test, lookup) + testDurationFor(test) + waitMargin
+}
+
+// suiteWaitTimeoutFor returns how long the CLI waits for the given suite running the given tests to finish. Parallel
+// suites take as long as their slowest test, all other policies run one test after another.
+func suiteWaitTimeoutFor(suite *v1alpha1.TestSuite, tests []v1alpha1.Test, lookup testLookup) time.Duration {
+	var duration time.Duration
+	for i := range tests {
+		testDuration := dependenciesDurationFor(&tests[i], lookup) + testDurationFor(&tests[i])
+		if suite.Spec.Policy == v1alpha1.TestSuitePolicyParallel {
+			if testDuration > duration {
+				duration = testDuration
+			}
+		} else {
+			duration += testDuration
+		}
+	}
+	return duration + waitMargin
+}
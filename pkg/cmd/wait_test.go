@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func noTests(string) *v1alpha1.Test {
+	return nil
+}
+
+func TestWaitTimeoutFor(t *testing.T) {
+	test := &v1alpha1.Test{Spec: v1alpha1.TestSpec{Timeout: "1h"}}
+	assert.Equal(t, time.Hour+waitMargin, waitTimeoutFor(test, noTests))
+
+	test.Spec.Retries = 2
+	test.Spec.RetryBackoff = "1m"
+	assert.Equal(t, 3*time.Hour+3*time.Minute+waitMargin, waitTimeoutFor(test, noTests))
+}
+
+func TestSuiteWaitTimeoutFor(t *testing.T) {
+	suite := &v1alpha1.TestSuite{
+		Spec: v1alpha1.TestSuiteSpec{
+			Tests: []v1alpha1.TestSuiteEntry{
+				{Name: "short", Spec: &v1alpha1.TestSpec{Timeout: "10m"}},
+				{Name: "long", Ref: "long-test"},
+			},
+		},
+	}
+	tests := []v1alpha1.Test{
+		{Spec: v1alpha1.TestSpec{Timeout: "10m"}},
+		{Spec: v1alpha1.TestSpec{Timeout: "20m", Retries: 1, RetryBackoff: "5m"}},
+	}
+	assert.Equal(t, 55*time.Minute+waitMargin, suiteWaitTimeoutFor(suite, tests, noTests))
+
+	suite.Spec.Policy = v1alpha1.TestSuitePolicyParallel
+	assert.Equal(t, 45*time.Minute+waitMargin, suiteWaitTimeoutFor(suite, tests, noTests))
+}
+
+func TestWaitTimeoutForDependencies(t *testing.T) {
+	tests := map[string]*v1alpha1.Test{
+		"provisioning": {Spec: v1alpha1.TestSpec{Timeout: "10m", DependsOn: []string{"setup"}}},
+		"setup":        {Spec: v1alpha1.TestSpec{Timeout: "5m"}},
+		"cleanup":      {Spec: v1alpha1.TestSpec{Timeout: "1m", DependsOn: []string{"cleanup"}}},
+	}
+	lookup := func(name string) *v1alpha1.Test {
+		return tests[name]
+	}
+
+	test := &v1alpha1.Test{Spec: v1alpha1.TestSpec{Timeout: "1h", DependsOn: []string{"provisioning", "cleanup", "missing"}}}
+	assert.Equal(t, 15*time.Minute, dependenciesDurationFor(test, lookup))
+	assert.Equal(t, time.Hour+15*time.Minute+waitMargin, waitTimeoutFor(test, lookup))
+}
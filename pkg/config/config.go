@@ -14,6 +14,58 @@ func GetTestBaseImage() string {
 	return getDefaultTestBaseImage()
 }
 
+// GetMavenSettings returns the operator wide reference to a Maven settings.xml (configmap:name[/key] or secret:name[/key])
+func GetMavenSettings() string {
+	return os.Getenv("YAKS_MAVEN_SETTINGS")
+}
+
+// GetMavenMirror returns the operator wide Maven mirror repository url
+func GetMavenMirror() string {
+	return os.Getenv("YAKS_MAVEN_MIRROR")
+}
+
+// GetMavenMirrorSecret returns the name of the secret holding the mirror credentials as keys username and password
+func GetMavenMirrorSecret() string {
+	return os.Getenv("YAKS_MAVEN_MIRROR_SECRET")
+}
+
+// GetMavenCacheSize returns the size of the shared Maven repository cache. The cache is disabled when no size is given.
+func GetMavenCacheSize() string {
+	return os.Getenv("YAKS_MAVEN_CACHE_SIZE")
+}
+
+// GetMavenCacheStorageClass returns the storage class of the shared Maven repository cache
+func GetMavenCacheStorageClass() string {
+	return os.Getenv("YAKS_MAVEN_CACHE_STORAGE_CLASS")
+}
+
+// GetMavenCacheAccessMode returns the access mode of the shared Maven repository cache
+func GetMavenCacheAccessMode() string {
+	customEnv := os.Getenv("YAKS_MAVEN_CACHE_ACCESS_MODE")
+	if customEnv != "" {
+		return customEnv
+	}
+	return "ReadWriteMany"
+}
+
+// DefaultTestTimeout is the maximum duration of a test run when neither the test nor the operator define a timeout
+const DefaultTestTimeout = "30m"
+
+// GetTestTimeout returns the default maximum duration of a test run
+func GetTestTimeout() string {
+	customEnv := os.Getenv("YAKS_TEST_TIMEOUT")
+	if customEnv != "" {
+		return customEnv
+	}
+	return DefaultTestTimeout
+}
+
+// GetWebhookCertDir returns the directory holding the serving certificate of the admission webhooks.
+// The webhooks are disabled when no directory is given.
+func GetWebhookCertDir() string {
+	return os.Getenv("YAKS_WEBHOOK_CERT_DIR")
+}
+
 func getDefaultTestBaseImage() string {
 	return "yaks/yaks:" + version.Version
 }
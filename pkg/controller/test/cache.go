@@ -0,0 +1,189 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/citrusframework/yaks/pkg/config"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// MavenCacheName is the name of the persistent volume claim holding the shared Maven repository of a namespace
+	MavenCacheName = "yaks-maven-repository"
+
+	mavenCacheVolume   = "maven-cache"
+	mavenCachePath     = "/deployments/m2-cache"
+	mavenOverlayVolume = "maven-repository"
+	mavenOverlayPath   = "/deployments/m2-overlay"
+	mavenBaseRepo      = "/deployments/artifacts/m2"
+
+	// mavenCacheMarker marks the point in time when the overlay has been restored from the cache
+	mavenCacheMarker = ".yaks-restored"
+)
+
+// mavenCacheEnabled tells whether the operator is configured to provide a shared Maven repository cache
+func mavenCacheEnabled() bool {
+	return config.GetMavenCacheSize() != ""
+}
+
+// ensureMavenCache creates the persistent volume claim of the shared Maven repository in the given namespace
+// unless it already exists
+func (action *startAction) ensureMavenCache(ctx context.Context, namespace string) error {
+	pvc := v1.PersistentVolumeClaim{}
+	key := client.ObjectKey{
+		Name:      MavenCacheName,
+		Namespace: namespace,
+	}
+
+	err := action.client.Get(ctx, key, &pvc)
+	if err == nil || !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	size, err := resource.ParseQuantity(config.GetMavenCacheSize())
+	if err != nil {
+		return fmt.Errorf("invalid Maven cache size '%s': %v", config.GetMavenCacheSize(), err)
+	}
+
+	pvc = v1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: v1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      MavenCacheName,
+			Labels: map[string]string{
+				"org.citrusframework.yaks/app": "yaks",
+			},
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{
+				v1.PersistentVolumeAccessMode(config.GetMavenCacheAccessMode()),
+			},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+
+	if storageClass := config.GetMavenCacheStorageClass(); storageClass != "" {
+		pvc.Spec.StorageClassName = &storageClass
+	}
+
+	err = action.client.Create(ctx, &pvc)
+	if err != nil && k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// configureMavenCache lets the testing pod use the shared Maven repository. The shared repository is never used by
+// Maven directly. An init container restores the image repository and the shared repository into a per pod overlay
+// that Maven works on. After the test the artifacts downloaded by Maven are written back to the shared repository.
+// Each file is copied to a temporary file first and then moved to its final location so concurrent pods never see
+// partially written artifacts.
+func configureMavenCache(pod *v1.Pod) {
+	container := &pod.Spec.Containers[0]
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes,
+		v1.Volume{
+			Name: mavenCacheVolume,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: MavenCacheName,
+				},
+			},
+		},
+		v1.Volume{
+			Name: mavenOverlayVolume,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		},
+	)
+
+	restore := []string{
+		"set -e",
+		fmt.Sprintf("cp -R %s/. %s/", mavenBaseRepo, mavenOverlayPath),
+		fmt.Sprintf("if [ -d %s/repository ]; then cp -R %s/repository/. %s/; fi", mavenCachePath, mavenCachePath, mavenOverlayPath),
+		fmt.Sprintf("touch %s/%s", mavenOverlayPath, mavenCacheMarker),
+	}
+
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, v1.Container{
+		Name:            "maven-cache",
+		Image:           container.Image,
+		ImagePullPolicy: container.ImagePullPolicy,
+		Command:         []string{"/bin/sh", "-c", strings.Join(restore, "\n")},
+		VolumeMounts: []v1.VolumeMount{
+			{
+				Name:      mavenCacheVolume,
+				MountPath: mavenCachePath,
+				ReadOnly:  true,
+			},
+			{
+				Name:      mavenOverlayVolume,
+				MountPath: mavenOverlayPath,
+			},
+		},
+	})
+
+	command := make([]string, 0, len(container.Command))
+	for _, arg := range container.Command {
+		if strings.HasPrefix(arg, "-Dmaven.repo.local=") {
+			arg = "-Dmaven.repo.local=" + mavenOverlayPath
+		}
+		command = append(command, shellQuote(arg))
+	}
+
+	script := []string{
+		strings.Join(command, " "),
+		"result=$?",
+		fmt.Sprintf("cd %s && find . -type f -newer %s ! -name '*.lastUpdated' ! -name '*.part' ! -name '*.lock' | while read -r file; do", mavenOverlayPath, mavenCacheMarker),
+		fmt.Sprintf("  target=\"%s/repository/$file\"", mavenCachePath),
+		"  mkdir -p \"$(dirname \"$target\")\" && cp \"$file\" \"$target.$HOSTNAME.tmp\" && mv -f \"$target.$HOSTNAME.tmp\" \"$target\"",
+		"done",
+		"exit $result",
+	}
+	container.Command = []string{"/bin/sh", "-c", strings.Join(script, "\n")}
+
+	container.VolumeMounts = append(container.VolumeMounts,
+		v1.VolumeMount{
+			Name:      mavenCacheVolume,
+			MountPath: mavenCachePath,
+		},
+		v1.VolumeMount{
+			Name:      mavenOverlayVolume,
+			MountPath: mavenOverlayPath,
+		},
+	)
+}
+
+func shellQuote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
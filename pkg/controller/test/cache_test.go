@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestConfigureMavenCache(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:            "test",
+					Image:           "yaks",
+					ImagePullPolicy: v1.PullIfNotPresent,
+					Command:         []string{"mvn", "-Dmaven.repo.local=/deployments/artifacts/m2", "-Dcucumber.options=--tags 'not @ignored'", "test"},
+				},
+			},
+		},
+	}
+
+	configureMavenCache(pod)
+
+	assert.Len(t, pod.Spec.Volumes, 2)
+	assert.Equal(t, MavenCacheName, pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.NotNil(t, pod.Spec.Volumes[1].EmptyDir)
+
+	assert.Len(t, pod.Spec.InitContainers, 1)
+	init := pod.Spec.InitContainers[0]
+	assert.Equal(t, "yaks", init.Image)
+	assert.Equal(t, v1.PullIfNotPresent, init.ImagePullPolicy)
+	assert.True(t, init.VolumeMounts[0].ReadOnly)
+	assert.Equal(t, []string{
+		"set -e",
+		"cp -R /deployments/artifacts/m2/. /deployments/m2-overlay/",
+		"if [ -d /deployments/m2-cache/repository ]; then cp -R /deployments/m2-cache/repository/. /deployments/m2-overlay/; fi",
+		"touch /deployments/m2-overlay/.yaks-restored",
+	}, strings.Split(init.Command[2], "\n"))
+
+	container := pod.Spec.Containers[0]
+	assert.Equal(t, "/bin/sh", container.Command[0])
+	script := strings.Split(container.Command[2], "\n")
+	assert.Equal(t, `'mvn' '-Dmaven.repo.local=/deployments/m2-overlay' '-Dcucumber.options=--tags '\''not @ignored'\''' 'test'`, script[0])
+	assert.Equal(t, "result=$?", script[1])
+	assert.Contains(t, script[2], "find . -type f -newer .yaks-restored ! -name '*.lastUpdated' ! -name '*.part' ! -name '*.lock'")
+	assert.Equal(t, `  target="/deployments/m2-cache/repository/$file"`, script[3])
+	assert.Contains(t, script[4], `mv -f "$target.$HOSTNAME.tmp" "$target"`)
+	assert.Equal(t, "exit $result", script[len(script)-1])
+
+	assert.Len(t, container.VolumeMounts, 2)
+	assert.False(t, container.VolumeMounts[0].ReadOnly)
+	assert.Equal(t, "/deployments/m2-overlay", container.VolumeMounts[1].MountPath)
+}
@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// testLookup returns the test with the given name or nil if there is no such test
+type testLookup func(name string) (*v1alpha1.Test, error)
+
+// lookupIn returns a lookup for tests in the given namespace
+func lookupIn(ctx context.Context, c client.Reader, namespace string) testLookup {
+	return func(name string) (*v1alpha1.Test, error) {
+		test := v1alpha1.Test{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &test); err != nil && k8serrors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return &test, nil
+	}
+}
+
+// CheckDependencyCycle returns an error if following the dependencies of the test through the tests in its namespace
+// leads back to the test itself
+func CheckDependencyCycle(ctx context.Context, c client.Reader, test *v1alpha1.Test) error {
+	return findDependencyCycle(test, lookupIn(ctx, c, test.Namespace))
+}
+
+func findDependencyCycle(test *v1alpha1.Test, lookup testLookup) error {
+	visited := make(map[string]bool)
+	var visit func(path []string, dependsOn []string) error
+	visit = func(path []string, dependsOn []string) error {
+		for _, name := range dependsOn {
+			next := append(append([]string{}, path...), name)
+			if name == test.Name {
+				return fmt.Errorf("dependency cycle %s", strings.Join(next, " -> "))
+			}
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+
+			dependency, err := lookup(name)
+			if err != nil {
+				return err
+			}
+			if dependency == nil {
+				continue
+			}
+			if err := visit(next, dependency.Spec.DependsOn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return visit([]string{test.Name}, test.Spec.DependsOn)
+}
+
+// dependencyStatus returns the dependencies of the test that have not passed yet and the first dependency that did
+// not pass in the end. Dependencies that do not exist yet are waited for.
+func dependencyStatus(test *v1alpha1.Test, lookup testLookup) ([]string, *v1alpha1.Test, error) {
+	waiting := make([]string, 0)
+	for _, name := range test.Spec.DependsOn {
+		dependency, err := lookup(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if dependency == nil {
+			waiting = append(waiting, name)
+			continue
+		}
+
+		switch dependency.Status.Phase {
+		case v1alpha1.TestPhasePassed:
+		case v1alpha1.TestPhaseFailed, v1alpha1.TestPhaseError, v1alpha1.TestPhaseSkipped:
+			return nil, dependency, nil
+		default:
+			waiting = append(waiting, name)
+		}
+	}
+	return waiting, nil, nil
+}
+
+// checkDependencies tells whether the test is ready to start. Tests wait in pending phase until all their dependencies
+// have passed and get skipped as soon as a dependency fails.
+func (action *startAction) checkDependencies(ctx context.Context, test *v1alpha1.Test) (bool, error) {
+	lookup := lookupIn(ctx, action.client, test.Namespace)
+	if err := findDependencyCycle(test, lookup); err != nil {
+		test.Status.Phase = v1alpha1.TestPhaseError
+		test.Status.Errors = v1alpha1.TestErrors{{Test: test.Name, Message: err.Error()}}
+		return false, nil
+	}
+
+	waiting, failed, err := dependencyStatus(test, lookup)
+	if err != nil {
+		return false, err
+	}
+
+	if failed != nil {
+		test.Status.Phase = v1alpha1.TestPhaseSkipped
+		test.Status.SetCondition(v1alpha1.TestConditionWaitingForDependencies, v1.ConditionFalse, "DependencyFailed",
+			fmt.Sprintf("test %s did not pass (%s)", failed.Name, failed.Status.Phase))
+		return false, nil
+	}
+
+	if len(waiting) > 0 {
+		test.Status.SetCondition(v1alpha1.TestConditionWaitingForDependencies, v1.ConditionTrue, "DependenciesNotPassed",
+			fmt.Sprintf("waiting for tests %s to pass", strings.Join(waiting, ", ")))
+		return false, nil
+	}
+
+	test.Status.SetCondition(v1alpha1.TestConditionWaitingForDependencies, v1.ConditionFalse, "DependenciesPassed",
+		"all tests the test depends on have passed")
+	return true, nil
+}
+
+// dependsOn tells whether the test depends on the test with the given name
+func dependsOn(test *v1alpha1.Test, name string) bool {
+	for _, dependency := range test.Spec.DependsOn {
+		if dependency == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"testing"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newDependentTest(name string, phase v1alpha1.TestPhase, dependsOn ...string) *v1alpha1.Test {
+	return &v1alpha1.Test{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1alpha1.TestSpec{DependsOn: dependsOn},
+		Status:     v1alpha1.TestStatus{Phase: phase},
+	}
+}
+
+func lookupFrom(tests ...*v1alpha1.Test) testLookup {
+	return func(name string) (*v1alpha1.Test, error) {
+		for _, test := range tests {
+			if test.Name == name {
+				return test, nil
+			}
+		}
+		return nil, nil
+	}
+}
+
+func TestFindDependencyCycle(t *testing.T) {
+	test := newDependentTest("consumer", v1alpha1.TestPhasePending, "provisioning", "producer")
+	lookup := lookupFrom(
+		newDependentTest("provisioning", v1alpha1.TestPhasePassed),
+		newDependentTest("producer", v1alpha1.TestPhaseRunning, "provisioning"),
+	)
+	assert.Nil(t, findDependencyCycle(test, lookup))
+
+	lookup = lookupFrom(
+		newDependentTest("provisioning", v1alpha1.TestPhasePassed, "cleanup"),
+		newDependentTest("cleanup", v1alpha1.TestPhasePending, "consumer"),
+	)
+	err := findDependencyCycle(test, lookup)
+	assert.NotNil(t, err)
+	assert.Equal(t, "dependency cycle consumer -> provisioning -> cleanup -> consumer", err.Error())
+}
+
+func TestDependencyStatus(t *testing.T) {
+	test := newDependentTest("consumer", v1alpha1.TestPhasePending, "provisioning", "producer")
+
+	waiting, failed, err := dependencyStatus(test, lookupFrom(
+		newDependentTest("provisioning", v1alpha1.TestPhasePassed),
+	))
+	assert.Nil(t, err)
+	assert.Nil(t, failed)
+	assert.Equal(t, []string{"producer"}, waiting)
+
+	waiting, failed, err = dependencyStatus(test, lookupFrom(
+		newDependentTest("provisioning", v1alpha1.TestPhasePassed),
+		newDependentTest("producer", v1alpha1.TestPhasePassed),
+	))
+	assert.Nil(t, err)
+	assert.Nil(t, failed)
+	assert.Empty(t, waiting)
+
+	_, failed, err = dependencyStatus(test, lookupFrom(
+		newDependentTest("provisioning", v1alpha1.TestPhaseRunning),
+		newDependentTest("producer", v1alpha1.TestPhaseSkipped),
+	))
+	assert.Nil(t, err)
+	assert.Equal(t, "producer", failed.Name)
+}
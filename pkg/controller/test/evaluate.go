@@ -20,12 +20,15 @@ package test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"path"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+	"time"
 )
 
 // NewEvaluateAction creates a new evaluate action
@@ -56,6 +59,16 @@ func (action *evaluateAction) Handle(ctx context.Context, test *v1alpha1.Test) (
 		return nil, err
 	}
 
+	if status.Phase == v1.PodFailed && status.Reason == "DeadlineExceeded" {
+		timeout, _ := TimeoutFor(test)
+		test.Status.Phase = v1alpha1.TestPhaseError
+		test.Status.Errors = v1alpha1.TestErrors{
+			{Test: test.Name, Type: "Timeout", Message: fmt.Sprintf("test timed out after %s", timeout)},
+		}
+		finishAttempt(test, time.Now())
+		return test, nil
+	}
+
 	if status.Phase == v1.PodSucceeded {
 		test.Status.Phase = v1alpha1.TestPhasePassed
 		err = action.addTestResults(status, test)
@@ -68,6 +81,10 @@ func (action *evaluateAction) Handle(ctx context.Context, test *v1alpha1.Test) (
 		return nil, err
 	}
 
+	if test.Status.Phase != v1alpha1.TestPhaseRunning {
+		finishAttempt(test, time.Now())
+	}
+
 	return test, nil
 }
 
@@ -77,25 +94,43 @@ func (action *evaluateAction) addTestResults(status v1.PodStatus, test *v1alpha1
 		return err
 	}
 
-	errors := make([]string, 0)
+	terminated := status.ContainerStatuses[0].State.Terminated
+	if !terminated.StartedAt.IsZero() && !terminated.FinishedAt.IsZero() {
+		test.Status.Results.Duration = terminated.FinishedAt.Sub(terminated.StartedAt.Time).String()
+	}
+
+	errors := make(v1alpha1.TestErrors, 0)
 	for _, result := range test.Status.Results.Tests {
 		if result.ErrorType != "" {
-			_, testName := path.Split(result.Name)
-			errors = append(errors, testName + " failed with " + result.ErrorType + ": " + result.ErrorMessage)
+			errors = append(errors, testErrorFor(result))
 		}
 	}
 
 	if len(errors) > 0 {
-		bytes, err := json.Marshal(errors)
-		if err != nil {
-			return err
-		}
-		test.Status.Errors = string(bytes);
+		test.Status.Errors = errors
 	}
 
 	return nil
 }
 
+// testErrorFor creates the error of a failed scenario. The result name is the feature file URI followed by the line
+// of the scenario.
+func testErrorFor(result v1alpha1.TestResult) v1alpha1.TestError {
+	_, location := path.Split(result.Name)
+	feature := location
+	if i := strings.LastIndex(location, ":"); i > 0 {
+		feature = location[:i]
+	}
+
+	return v1alpha1.TestError{
+		Test:     feature,
+		Scenario: result.Scenario,
+		Type:     result.ErrorType,
+		Message:  result.ErrorMessage,
+		Location: location,
+	}
+}
+
 func (action *evaluateAction) getTestPodStatus(ctx context.Context, test *v1alpha1.Test) (v1.PodStatus, error) {
 	pod := v1.Pod{
 		TypeMeta: metav1.TypeMeta{
@@ -56,5 +56,8 @@ func (action *initializeAction) Handle(ctx context.Context, test *v1alpha1.Test)
 	test.Status.TestID = xid.New().String()
 	test.Status.Digest = testDigest
 	test.Status.Version = version.Version
+	test.Status.Conditions = nil
+	test.Status.Attempts = nil
+	test.Status.RetryTime = nil
 	return test, nil
 }
@@ -42,7 +42,8 @@ func (action *monitorAction) Name() string {
 func (action *monitorAction) CanHandle(build *v1alpha1.Test) bool {
 	return build.Status.Phase == v1alpha1.TestPhaseFailed ||
 		build.Status.Phase == v1alpha1.TestPhasePassed ||
-		build.Status.Phase == v1alpha1.TestPhaseError
+		build.Status.Phase == v1alpha1.TestPhaseError ||
+		build.Status.Phase == v1alpha1.TestPhaseSkipped
 }
 
 // Handle handles the test
@@ -0,0 +1,164 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	testSourcesVolume = "test-sources"
+	testSourcesPath   = "/etc/yaks/sources"
+	testPartsVolume   = "test-parts"
+	testPartsPath     = "/etc/yaks/parts"
+)
+
+// partedFile is a test file whose content is stored in config map parts instead of the test custom resource
+type partedFile struct {
+	Name  string
+	Parts []v1.ConfigMapKeySelector
+}
+
+// partedFilesFor returns all sources, settings and resources of the test that reference their content as parts
+func partedFilesFor(test *v1alpha1.Test) []partedFile {
+	files := make([]partedFile, 0)
+	if len(test.Spec.Source.Parts) > 0 {
+		files = append(files, partedFile{Name: test.Spec.Source.Name, Parts: test.Spec.Source.Parts})
+	}
+	if test.Spec.Settings.Name != "" && len(test.Spec.Settings.Parts) > 0 {
+		files = append(files, partedFile{Name: test.Spec.Settings.Name, Parts: test.Spec.Settings.Parts})
+	}
+	for _, source := range test.Spec.Sources {
+		if len(source.Parts) > 0 {
+			files = append(files, partedFile{Name: source.Name, Parts: source.Parts})
+		}
+	}
+	for _, resource := range test.Spec.Resources {
+		if len(resource.Parts) > 0 {
+			files = append(files, partedFile{Name: resource.Name, Parts: resource.Parts})
+		}
+	}
+	return files
+}
+
+// partProjection projects a single config map key to the given path
+func partProjection(part v1.ConfigMapKeySelector, filePath string) v1.VolumeProjection {
+	return v1.VolumeProjection{
+		ConfigMap: &v1.ConfigMapProjection{
+			LocalObjectReference: part.LocalObjectReference,
+			Items: []v1.KeyToPath{
+				{
+					Key:  part.Key,
+					Path: filePath,
+				},
+			},
+			Optional: part.Optional,
+		},
+	}
+}
+
+// configureTestParts assembles the test files that are split into several config map parts. Files consisting of a
+// single part are projected into the tests directory directly. When there are files with more parts, the tests
+// directory becomes an empty dir volume. An init container copies the projected test files into it and concatenates
+// the parts of each file in order.
+func configureTestParts(pod *v1.Pod, test *v1alpha1.Test) {
+	files := make([]partedFile, 0)
+	for _, file := range partedFilesFor(test) {
+		if len(file.Parts) > 1 {
+			files = append(files, file)
+		}
+	}
+	if len(files) == 0 {
+		return
+	}
+
+	container := &pod.Spec.Containers[0]
+	projections := make([]v1.VolumeProjection, 0)
+	script := []string{
+		"set -e",
+		fmt.Sprintf("cp -RL %s/. %s/", testSourcesPath, testsPath),
+		// drop the internal directories of the projected volume
+		fmt.Sprintf("rm -rf %s/..?*", testsPath),
+	}
+
+	for i, file := range files {
+		parts := make([]string, 0, len(file.Parts))
+		for j, part := range file.Parts {
+			partPath := fmt.Sprintf("%d/%d", i, j)
+			projections = append(projections, partProjection(part, partPath))
+			parts = append(parts, shellQuote(path.Join(testPartsPath, partPath)))
+		}
+
+		target := path.Join(testsPath, file.Name)
+		script = append(script,
+			fmt.Sprintf("mkdir -p %s", shellQuote(path.Dir(target))),
+			fmt.Sprintf("cat %s > %s", strings.Join(parts, " "), shellQuote(target)),
+		)
+	}
+
+	for i := range pod.Spec.Volumes {
+		if pod.Spec.Volumes[i].Name == testsVolume {
+			pod.Spec.Volumes[i].Name = testSourcesVolume
+		}
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes,
+		v1.Volume{
+			Name: testsVolume,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		},
+		v1.Volume{
+			Name: testPartsVolume,
+			VolumeSource: v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{
+					Sources: projections,
+				},
+			},
+		},
+	)
+
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, v1.Container{
+		Name:            "test-parts",
+		Image:           container.Image,
+		ImagePullPolicy: container.ImagePullPolicy,
+		Command:         []string{"/bin/sh", "-c", strings.Join(script, "\n")},
+		VolumeMounts: []v1.VolumeMount{
+			{
+				Name:      testSourcesVolume,
+				MountPath: testSourcesPath,
+				ReadOnly:  true,
+			},
+			{
+				Name:      testPartsVolume,
+				MountPath: testPartsPath,
+				ReadOnly:  true,
+			},
+			{
+				Name:      testsVolume,
+				MountPath: testsPath,
+			},
+		},
+	})
+}
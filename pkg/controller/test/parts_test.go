@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"testing"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func part(name string, key string) v1.ConfigMapKeySelector {
+	return v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: name}, Key: key}
+}
+
+func newPartsPod() *v1.Pod {
+	return &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "test", Image: "yaks"}},
+			Volumes: []v1.Volume{
+				{Name: testsVolume, VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{}}},
+			},
+		},
+	}
+}
+
+func TestSinglePartFiles(t *testing.T) {
+	test := &v1alpha1.Test{
+		Spec: v1alpha1.TestSpec{
+			Source: v1alpha1.SourceSpec{Name: "hello.feature", Parts: []v1.ConfigMapKeySelector{part("bundle-0", "hello.feature")}},
+		},
+	}
+
+	pod := newPartsPod()
+	configureTestParts(pod, test)
+	assert.Empty(t, pod.Spec.InitContainers)
+	assert.Len(t, pod.Spec.Volumes, 1)
+
+	files := partedFilesFor(test)
+	assert.Len(t, files, 1)
+	assert.Equal(t, "hello.feature", files[0].Name)
+}
+
+func TestMultiPartFiles(t *testing.T) {
+	test := &v1alpha1.Test{
+		Spec: v1alpha1.TestSpec{
+			Source: v1alpha1.SourceSpec{Name: "hello.feature", Content: "Feature: Hello"},
+			Resources: []v1alpha1.ResourceSpec{
+				{Name: "data/large.txt", Parts: []v1.ConfigMapKeySelector{part("bundle-0", "large"), part("bundle-1", "large")}},
+			},
+		},
+	}
+
+	pod := newPartsPod()
+	configureTestParts(pod, test)
+
+	volumes := make(map[string]v1.Volume)
+	for _, volume := range pod.Spec.Volumes {
+		volumes[volume.Name] = volume
+	}
+	assert.NotNil(t, volumes[testSourcesVolume].Projected)
+	assert.NotNil(t, volumes[testsVolume].EmptyDir)
+	assert.Len(t, volumes[testPartsVolume].Projected.Sources, 2)
+	assert.Equal(t, "0/1", volumes[testPartsVolume].Projected.Sources[1].ConfigMap.Items[0].Path)
+
+	assert.Len(t, pod.Spec.InitContainers, 1)
+	assert.Equal(t, "yaks", pod.Spec.InitContainers[0].Image)
+	assert.Contains(t, pod.Spec.InitContainers[0].Command[2], "mkdir -p '/etc/yaks/tests/data'")
+	assert.Contains(t, pod.Spec.InitContainers[0].Command[2], "cat '/etc/yaks/parts/0/0' '/etc/yaks/parts/0/1' > '/etc/yaks/tests/data/large.txt'")
+}
@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/rs/xid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultRetryBackoff = 10 * time.Second
+	maxRetryBackoff     = 5 * time.Minute
+)
+
+// RetryBackoffFor returns the time to wait before the given retry of the test. The backoff doubles with each retry.
+func RetryBackoffFor(test *v1alpha1.Test, retry int) (time.Duration, error) {
+	backoff := defaultRetryBackoff
+	if test.Spec.RetryBackoff != "" {
+		duration, err := time.ParseDuration(test.Spec.RetryBackoff)
+		if err != nil || duration <= 0 {
+			return 0, fmt.Errorf("invalid retry backoff '%s', expected a positive duration such as 10s", test.Spec.RetryBackoff)
+		}
+		backoff = duration
+	}
+
+	for i := 1; i < retry && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff, nil
+}
+
+// finishAttempt records the finished run of a test that may be retried. As long as the retry limit is not reached
+// a failed test goes back to pending with a new test ID so that a new pod runs the test after the retry backoff.
+func finishAttempt(test *v1alpha1.Test, now time.Time) {
+	if test.Spec.Retries <= 0 {
+		return
+	}
+
+	test.Status.Attempts = append(test.Status.Attempts, v1alpha1.TestAttempt{
+		TestID:     test.Status.TestID,
+		Phase:      test.Status.Phase,
+		Summary:    test.Status.Results.Summary,
+		Errors:     test.Status.Errors.DeepCopy(),
+		FinishedAt: metav1.NewTime(now),
+	})
+	test.Status.RetryTime = nil
+
+	if test.Status.Phase == v1alpha1.TestPhasePassed {
+		markFlaky(test)
+		return
+	}
+
+	retry := len(test.Status.Attempts)
+	if retry > test.Spec.Retries {
+		return
+	}
+
+	backoff, err := RetryBackoffFor(test, retry)
+	if err != nil {
+		return
+	}
+	retryTime := metav1.NewTime(now.Add(backoff))
+
+	test.Status.Phase = v1alpha1.TestPhasePending
+	test.Status.TestID = xid.New().String()
+	test.Status.Results = v1alpha1.TestResults{}
+	test.Status.Errors = nil
+	test.Status.RetryTime = &retryTime
+}
+
+// markFlaky marks the scenarios that have passed after they failed in a previous attempt
+func markFlaky(test *v1alpha1.Test) {
+	failed := make(map[string]bool)
+	for _, attempt := range test.Status.Attempts {
+		for _, testError := range attempt.Errors {
+			if testError.Location != "" {
+				failed[testError.Location] = true
+			}
+		}
+	}
+
+	for i := range test.Status.Results.Tests {
+		result := &test.Status.Results.Tests[i]
+		if _, location := path.Split(result.Name); failed[location] && result.ErrorType == "" {
+			result.Flaky = true
+			test.Status.Results.Summary.Flaky++
+		}
+	}
+}
+
+// retryDelay returns the time left until a pending test may start its next attempt
+func retryDelay(test *v1alpha1.Test, now time.Time) time.Duration {
+	if test.Status.Phase != v1alpha1.TestPhasePending || test.Status.RetryTime == nil {
+		return 0
+	}
+	return test.Status.RetryTime.Sub(now)
+}
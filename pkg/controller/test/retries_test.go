@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBackoffFor(t *testing.T) {
+	test := &v1alpha1.Test{}
+
+	backoff, err := RetryBackoffFor(test, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 10*time.Second, backoff)
+
+	backoff, _ = RetryBackoffFor(test, 3)
+	assert.Equal(t, 40*time.Second, backoff)
+
+	backoff, _ = RetryBackoffFor(test, 20)
+	assert.Equal(t, 5*time.Minute, backoff)
+
+	test.Spec.RetryBackoff = "1m"
+	backoff, _ = RetryBackoffFor(test, 2)
+	assert.Equal(t, 2*time.Minute, backoff)
+
+	test.Spec.RetryBackoff = "-1s"
+	_, err = RetryBackoffFor(test, 1)
+	assert.NotNil(t, err)
+}
+
+func TestFinishAttempt(t *testing.T) {
+	now := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+	test := &v1alpha1.Test{
+		Spec: v1alpha1.TestSpec{Retries: 1},
+		Status: v1alpha1.TestStatus{
+			Phase:  v1alpha1.TestPhaseFailed,
+			TestID: "first",
+			Results: v1alpha1.TestResults{
+				Summary: v1alpha1.TestSummary{Total: 2, Passed: 1, Failed: 1},
+			},
+			Errors: v1alpha1.TestErrors{{Scenario: "Order", Location: "order.feature:12"}},
+		},
+	}
+
+	finishAttempt(test, now)
+	assert.Equal(t, v1alpha1.TestPhasePending, test.Status.Phase)
+	assert.NotEqual(t, "first", test.Status.TestID)
+	assert.Empty(t, test.Status.Errors)
+	assert.Equal(t, now.Add(10*time.Second), test.Status.RetryTime.Time)
+	assert.Equal(t, 10*time.Second, retryDelay(test, now))
+	assert.Len(t, test.Status.Attempts, 1)
+	assert.Equal(t, 1, test.Status.Attempts[0].Summary.Failed)
+
+	test.Status.Phase = v1alpha1.TestPhasePassed
+	test.Status.Results = v1alpha1.TestResults{
+		Summary: v1alpha1.TestSummary{Total: 2, Passed: 2},
+		Tests: []v1alpha1.TestResult{
+			{Name: "classpath:org/citrusframework/yaks/order.feature:12", Scenario: "Order"},
+			{Name: "classpath:org/citrusframework/yaks/order.feature:20", Scenario: "Cancel"},
+		},
+	}
+
+	finishAttempt(test, now)
+	assert.Equal(t, v1alpha1.TestPhasePassed, test.Status.Phase)
+	assert.Nil(t, test.Status.RetryTime)
+	assert.Len(t, test.Status.Attempts, 2)
+	assert.True(t, test.Status.Results.Tests[0].Flaky)
+	assert.False(t, test.Status.Results.Tests[1].Flaky)
+	assert.Equal(t, 1, test.Status.Results.Summary.Flaky)
+}
+
+func TestFinishAttemptRetriesExceeded(t *testing.T) {
+	test := &v1alpha1.Test{
+		Spec: v1alpha1.TestSpec{Retries: 1},
+		Status: v1alpha1.TestStatus{
+			Phase:    v1alpha1.TestPhaseFailed,
+			Attempts: []v1alpha1.TestAttempt{{Phase: v1alpha1.TestPhaseFailed}},
+		},
+	}
+
+	finishAttempt(test, time.Now())
+	assert.Equal(t, v1alpha1.TestPhaseFailed, test.Status.Phase)
+	assert.Len(t, test.Status.Attempts, 2)
+	assert.Nil(t, test.Status.RetryTime)
+
+	test = &v1alpha1.Test{Status: v1alpha1.TestStatus{Phase: v1alpha1.TestPhaseFailed}}
+	finishAttempt(test, time.Now())
+	assert.Equal(t, v1alpha1.TestPhaseFailed, test.Status.Phase)
+	assert.Empty(t, test.Status.Attempts)
+}
@@ -19,12 +19,17 @@ package test
 
 import (
 	"context"
+	"fmt"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
 	"github.com/citrusframework/yaks/pkg/config"
 	"github.com/citrusframework/yaks/pkg/install"
+	"github.com/citrusframework/yaks/pkg/util/envvar"
 	"github.com/citrusframework/yaks/pkg/util/kubernetes"
+	"github.com/citrusframework/yaks/pkg/util/maven"
 	snap "github.com/container-tools/snap/pkg/api"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/api/rbac/v1beta1"
@@ -34,6 +39,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// maxConfigMapSize is the maximum size of test content stored in a single config map
+const maxConfigMapSize = 900 * 1024
+
+const (
+	testsVolume = "tests"
+	testsPath   = "/etc/yaks/tests"
+
+	mavenSettingsVolume = "maven-settings"
+	mavenSettingsPath   = "/etc/yaks/maven"
+
+	// secretsPath is the parent directory of mounted secrets that do not specify a mount path
+	secretsPath = "/etc/yaks/secrets"
+	// configMapsPath is the parent directory of mounted config maps that do not specify a mount path
+	configMapsPath = "/etc/yaks/configmaps"
+)
+
 // NewStartAction creates a new start action
 func NewStartAction() Action {
 	return &startAction{}
@@ -60,12 +81,63 @@ func (action *startAction) Handle(ctx context.Context, test *v1alpha1.Test) (*v1
 		return nil, err
 	}
 
-	cm := action.newTestingConfigMap(ctx, test)
-	pod, err := action.newTestingPod(ctx, test, cm)
+	if err := Validate(test); err != nil {
+		test.Status.Phase = v1alpha1.TestPhaseError
+		test.Status.Errors = v1alpha1.TestErrors{{Test: test.Name, Message: err.Error()}}
+		return test, nil
+	}
+
+	if retryDelay(test, time.Now()) > 0 {
+		// wait for the retry backoff, the test gets reconciled again once it has passed
+		return nil, nil
+	}
+
+	if len(test.Spec.DependsOn) > 0 {
+		if ready, err := action.checkDependencies(ctx, test); err != nil {
+			return nil, err
+		} else if !ready {
+			return test, nil
+		}
+	}
+
+	mavenSpec, err := mavenSpecFor(test)
+	if err != nil {
+		test.Status.Phase = v1alpha1.TestPhaseError
+		test.Status.Errors = v1alpha1.TestErrors{{Test: test.Name, Message: err.Error()}}
+		return test, nil
+	}
+
+	configMaps, projections := action.newTestingConfigMaps(ctx, test)
+	if mavenSpec.Settings == nil && mavenSpec.Mirror != "" {
+		settings, err := maven.NewMirrorSettings(mavenSpec)
+		if err != nil {
+			return nil, err
+		}
+
+		cm := action.newTestingConfigMap(test, MavenSettingsNameFor(test))
+		cm.Data[maven.SettingsFileName] = settings
+		configMaps = append(configMaps, cm)
+	}
+
+	pod, err := action.newTestingPod(ctx, test, projections)
 	if err != nil {
 		return nil, err
 	}
-	resources := []runtime.Object{cm, pod}
+	configureTestParts(pod, test)
+	configureMaven(pod, test, mavenSpec)
+
+	if mavenCacheEnabled() {
+		if err := action.ensureMavenCache(ctx, test.Namespace); err != nil {
+			return nil, err
+		}
+		configureMavenCache(pod)
+	}
+
+	resources := make([]runtime.Object, 0, len(configMaps)+1)
+	for _, cm := range configMaps {
+		resources = append(resources, cm)
+	}
+	resources = append(resources, pod)
 	if err := kubernetes.ReplaceResources(ctx, action.client, resources); err != nil {
 		return nil, err
 	}
@@ -74,7 +146,7 @@ func (action *startAction) Handle(ctx context.Context, test *v1alpha1.Test) (*v1
 	return test, nil
 }
 
-func (action *startAction) newTestingPod(ctx context.Context, test *v1alpha1.Test, cm *v1.ConfigMap) (*v1.Pod, error) {
+func (action *startAction) newTestingPod(ctx context.Context, test *v1alpha1.Test, projections []v1.VolumeProjection) (*v1.Pod, error) {
 	controller := true
 	blockOwnerDeletion := true
 	pod := v1.Pod{
@@ -122,8 +194,8 @@ func (action *startAction) newTestingPod(ctx context.Context, test *v1alpha1.Tes
 					ImagePullPolicy:          v1.PullIfNotPresent,
 					VolumeMounts: []v1.VolumeMount{
 						{
-							Name:      "tests",
-							MountPath: "/etc/yaks/tests",
+							Name:      testsVolume,
+							MountPath: testsPath,
 						},
 					},
 					Env: []v1.EnvVar{
@@ -133,7 +205,7 @@ func (action *startAction) newTestingPod(ctx context.Context, test *v1alpha1.Tes
 						},
 						{
 							Name:  "YAKS_TESTS_PATH",
-							Value: "/etc/yaks/tests",
+							Value: testsPath,
 						},
 					},
 				},
@@ -141,12 +213,10 @@ func (action *startAction) newTestingPod(ctx context.Context, test *v1alpha1.Tes
 			RestartPolicy: v1.RestartPolicyNever,
 			Volumes: []v1.Volume{
 				{
-					Name: "tests",
+					Name: testsVolume,
 					VolumeSource: v1.VolumeSource{
-						ConfigMap: &v1.ConfigMapVolumeSource{
-							LocalObjectReference: v1.LocalObjectReference{
-								Name: cm.Name,
-							},
+						Projected: &v1.ProjectedVolumeSource{
+							Sources: projections,
 						},
 					},
 				},
@@ -154,35 +224,81 @@ func (action *startAction) newTestingPod(ctx context.Context, test *v1alpha1.Tes
 		},
 	}
 
+	if suite, ok := test.Labels[v1alpha1.TestSuiteLabel]; ok {
+		pod.Labels[v1alpha1.TestSuiteLabel] = suite
+	}
+
 	for _, value := range test.Spec.Env {
 		pair := strings.SplitN(value, "=", 2)
 		if len(pair) == 2 {
 			k := strings.TrimSpace(pair[0])
 			v := strings.TrimSpace(pair[1])
 
-			if len(k) > 0 && len(v) > 0 {
-				pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, v1.EnvVar{
-					Name:  k,
-					Value: v,
-				})
+			if len(k) > 0 {
+				envvar.SetVal(&pod.Spec.Containers[0].Env, k, v)
 			}
 		}
 	}
 
+	for _, value := range test.Spec.EnvVars {
+		envvar.SetVar(&pod.Spec.Containers[0].Env, value)
+	}
+	pod.Spec.Containers[0].EnvFrom = append(pod.Spec.Containers[0].EnvFrom, test.Spec.EnvFrom...)
+
+	dependencies := make([]string, 0, len(test.Spec.Dependencies)+1)
 	if test.Spec.Settings.Name != "" {
 		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, v1.EnvVar{
 			Name:  "YAKS_SETTINGS_FILE",
-			Value: "/etc/yaks/tests/" + test.Spec.Settings.Name,
+			Value: path.Join(testsPath, test.Spec.Settings.Name),
 		},
 		)
 	} else if test.Spec.Settings.Content != "" {
+		dependencies = append(dependencies, test.Spec.Settings.Content)
+	}
+
+	for _, dependency := range test.Spec.Dependencies {
+		dependencies = append(dependencies, dependency.String())
+	}
+
+	if len(dependencies) > 0 {
 		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, v1.EnvVar{
 			Name:  "YAKS_DEPENDENCIES",
-			Value: test.Spec.Settings.Content,
+			Value: strings.Join(dependencies, ","),
 		},
 		)
 	}
 
+	if len(test.Spec.Repositories) > 0 {
+		repositories := make([]string, 0, len(test.Spec.Repositories))
+		for _, repository := range test.Spec.Repositories {
+			repositories = append(repositories, repository.ID+"="+repository.URL)
+		}
+
+		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, v1.EnvVar{
+			Name:  "YAKS_REPOSITORIES",
+			Value: strings.Join(repositories, ","),
+		},
+		)
+	}
+
+	if test.Spec.Runtime.Image != "" {
+		pod.Spec.Containers[0].Image = test.Spec.Runtime.Image
+	}
+	if test.Spec.Runtime.ImagePullPolicy != "" {
+		pod.Spec.Containers[0].ImagePullPolicy = test.Spec.Runtime.ImagePullPolicy
+	}
+	pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, test.Spec.Runtime.ImagePullSecrets...)
+
+	timeout, err := TimeoutFor(test)
+	if err != nil {
+		return nil, err
+	}
+	deadline := int64(timeout.Seconds())
+	pod.Spec.ActiveDeadlineSeconds = &deadline
+
+	mountSecretsAndConfigMaps(&pod, test)
+	customizePod(&pod, test.Spec.Runtime.Pod)
+
 	if err := action.injectSnap(ctx, &pod); err != nil {
 		return nil, err
 	}
@@ -190,17 +306,279 @@ func (action *startAction) newTestingPod(ctx context.Context, test *v1alpha1.Tes
 	return &pod, nil
 }
 
-func (action *startAction) newTestingConfigMap(ctx context.Context, test *v1alpha1.Test) *v1.ConfigMap {
-	controller := true
-	blockOwnerDeletion := true
+// mavenSpecFor returns the Maven settings of the test. The operator wide settings apply when the test
+// does not define any Maven settings on its own.
+func mavenSpecFor(test *v1alpha1.Test) (v1alpha1.MavenSpec, error) {
+	spec := test.Spec.Runtime.Maven
+	if spec.IsEmpty() {
+		if reference := config.GetMavenSettings(); reference != "" {
+			settings, err := maven.ParseSettingsReference(reference)
+			if err != nil {
+				return spec, err
+			}
+			spec.Settings = settings
+		}
 
-	sources := make(map[string]string)
-	sources[test.Spec.Source.Name] = test.Spec.Source.Content
+		spec.Mirror = config.GetMavenMirror()
 
-	if test.Spec.Settings.Name != "" {
-		sources[test.Spec.Settings.Name] = test.Spec.Settings.Content
+		if secret := config.GetMavenMirrorSecret(); secret != "" {
+			spec.Username, spec.Password = maven.MirrorCredentials(secret)
+		}
 	}
 
+	return spec, maven.ValidateSettings(spec)
+}
+
+// configureMaven mounts the Maven settings into the testing pod and passes the settings file to the Maven command.
+// Mirror credentials are exposed as environment variables read from secrets.
+func configureMaven(pod *v1.Pod, test *v1alpha1.Test, spec v1alpha1.MavenSpec) {
+	var source v1.VolumeSource
+	switch {
+	case spec.Settings != nil && spec.Settings.ConfigMap != nil:
+		source.ConfigMap = &v1.ConfigMapVolumeSource{
+			LocalObjectReference: spec.Settings.ConfigMap.LocalObjectReference,
+			Items:                []v1.KeyToPath{{Key: spec.Settings.ConfigMap.Key, Path: maven.SettingsFileName}},
+		}
+	case spec.Settings != nil && spec.Settings.Secret != nil:
+		source.Secret = &v1.SecretVolumeSource{
+			SecretName: spec.Settings.Secret.Name,
+			Items:      []v1.KeyToPath{{Key: spec.Settings.Secret.Key, Path: maven.SettingsFileName}},
+		}
+	case spec.Mirror != "":
+		source.ConfigMap = &v1.ConfigMapVolumeSource{
+			LocalObjectReference: v1.LocalObjectReference{Name: MavenSettingsNameFor(test)},
+		}
+	}
+
+	container := &pod.Spec.Containers[0]
+	if source.ConfigMap != nil || source.Secret != nil {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name:         mavenSettingsVolume,
+			VolumeSource: source,
+		})
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+			Name:      mavenSettingsVolume,
+			MountPath: mavenSettingsPath,
+			ReadOnly:  true,
+		})
+
+		for i := range container.Command {
+			if container.Command[i] == "-s" && i+1 < len(container.Command) {
+				container.Command[i+1] = path.Join(mavenSettingsPath, maven.SettingsFileName)
+			}
+		}
+	}
+
+	if spec.Username != nil {
+		container.Env = append(container.Env, v1.EnvVar{
+			Name:      maven.MirrorUsernameEnv,
+			ValueFrom: &v1.EnvVarSource{SecretKeyRef: spec.Username},
+		})
+	}
+	if spec.Password != nil {
+		container.Env = append(container.Env, v1.EnvVar{
+			Name:      maven.MirrorPasswordEnv,
+			ValueFrom: &v1.EnvVarSource{SecretKeyRef: spec.Password},
+		})
+	}
+}
+
+// mountSecretsAndConfigMaps mounts the secrets and config maps listed in the test into the test container
+func mountSecretsAndConfigMaps(pod *v1.Pod, test *v1alpha1.Test) {
+	container := &pod.Spec.Containers[0]
+
+	for i, secret := range test.Spec.Secrets {
+		name := fmt.Sprintf("yaks-secret-%d", i)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: name,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: secret.Name,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+			Name:      name,
+			MountPath: mountPathFor(secret, secretsPath),
+			ReadOnly:  true,
+		})
+	}
+
+	for i, cm := range test.Spec.ConfigMaps {
+		name := fmt.Sprintf("yaks-configmap-%d", i)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: name,
+			VolumeSource: v1.VolumeSource{
+				ConfigMap: &v1.ConfigMapVolumeSource{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: cm.Name,
+					},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+			Name:      name,
+			MountPath: mountPathFor(cm, configMapsPath),
+			ReadOnly:  true,
+		})
+	}
+}
+
+// mountPathFor returns the mount path of the given secret or config map. Objects without explicit path are mounted
+// into a directory named after the object below the given parent directory.
+func mountPathFor(mount v1alpha1.MountSpec, parent string) string {
+	if mount.Path != "" {
+		return mount.Path
+	}
+	return path.Join(parent, mount.Name)
+}
+
+// customizePod merges the pod customizations given in the test runtime spec into the testing pod
+func customizePod(pod *v1.Pod, spec v1alpha1.PodSpec) {
+	container := &pod.Spec.Containers[0]
+	container.Resources = spec.Resources
+
+	if len(spec.NodeSelector) > 0 {
+		pod.Spec.NodeSelector = spec.NodeSelector
+	}
+	pod.Spec.Tolerations = append(pod.Spec.Tolerations, spec.Tolerations...)
+
+	if spec.Affinity != nil {
+		pod.Spec.Affinity = spec.Affinity
+	}
+
+	if spec.SecurityContext != nil {
+		pod.Spec.SecurityContext = spec.SecurityContext
+	}
+
+	if spec.ServiceAccountName != "" {
+		pod.Spec.ServiceAccountName = spec.ServiceAccountName
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, spec.Volumes...)
+	container.VolumeMounts = append(container.VolumeMounts, spec.VolumeMounts...)
+}
+
+// newTestingConfigMaps creates the config maps holding the inline test content. The content is split across several
+// config maps when exceeding the config map size limit. The returned volume projections combine all config maps as well
+// as resources stored in user provided config maps and secrets into a single tests directory.
+func (action *startAction) newTestingConfigMaps(ctx context.Context, test *v1alpha1.Test) ([]*v1.ConfigMap, []v1.VolumeProjection) {
+	configMaps := make([]*v1.ConfigMap, 0)
+	projections := make([]v1.VolumeProjection, 0)
+	references := make([]v1.VolumeProjection, 0)
+
+	var cm *v1.ConfigMap
+	var items []v1.KeyToPath
+	size := 0
+
+	flush := func() {
+		if cm != nil {
+			configMaps = append(configMaps, cm)
+			projections = append(projections, v1.VolumeProjection{
+				ConfigMap: &v1.ConfigMapProjection{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: cm.Name,
+					},
+					Items: items,
+				},
+			})
+		}
+	}
+
+	addFile := func(name string, content string) {
+		if cm == nil || (size > 0 && size+len(content) > maxConfigMapSize) {
+			flush()
+
+			cmName := TestResourceNameFor(test)
+			if len(configMaps) > 0 {
+				cmName = TestResourcePartNameFor(test, len(configMaps))
+			}
+			cm = action.newTestingConfigMap(test, cmName)
+			items = make([]v1.KeyToPath, 0)
+			size = 0
+		}
+
+		// config map keys must not contain path separators so the key is mapped to the relative path of the file
+		key := kubernetes.SanitizeConfigMapKey(name)
+		for i := 1; ; i++ {
+			if _, exists := cm.Data[key]; !exists {
+				break
+			}
+			key = fmt.Sprintf("%s-%d", kubernetes.SanitizeConfigMapKey(name), i)
+		}
+
+		cm.Data[key] = content
+		items = append(items, v1.KeyToPath{
+			Key:  key,
+			Path: name,
+		})
+		size += len(content)
+	}
+
+	if len(test.Spec.Source.Parts) == 0 {
+		addFile(test.Spec.Source.Name, test.Spec.Source.Content)
+	}
+
+	if test.Spec.Settings.Name != "" && len(test.Spec.Settings.Parts) == 0 {
+		addFile(test.Spec.Settings.Name, test.Spec.Settings.Content)
+	}
+
+	for _, source := range test.Spec.Sources {
+		if len(source.Parts) == 0 {
+			addFile(source.Name, source.Content)
+		}
+	}
+
+	for _, resource := range test.Spec.Resources {
+		switch {
+		case len(resource.Parts) > 0:
+			// parted files are projected or assembled below
+		case resource.ConfigMap != nil:
+			references = append(references, v1.VolumeProjection{
+				ConfigMap: &v1.ConfigMapProjection{
+					LocalObjectReference: resource.ConfigMap.LocalObjectReference,
+					Items: []v1.KeyToPath{
+						{
+							Key:  resource.ConfigMap.Key,
+							Path: resource.Name,
+						},
+					},
+					Optional: resource.ConfigMap.Optional,
+				},
+			})
+		case resource.Secret != nil:
+			references = append(references, v1.VolumeProjection{
+				Secret: &v1.SecretProjection{
+					LocalObjectReference: resource.Secret.LocalObjectReference,
+					Items: []v1.KeyToPath{
+						{
+							Key:  resource.Secret.Key,
+							Path: resource.Name,
+						},
+					},
+					Optional: resource.Secret.Optional,
+				},
+			})
+		default:
+			addFile(resource.Name, resource.Content)
+		}
+	}
+
+	flush()
+
+	for _, file := range partedFilesFor(test) {
+		if len(file.Parts) == 1 {
+			references = append(references, partProjection(file.Parts[0], file.Name))
+		}
+	}
+
+	return configMaps, append(projections, references...)
+}
+
+func (action *startAction) newTestingConfigMap(test *v1alpha1.Test, name string) *v1.ConfigMap {
+	controller := true
+	blockOwnerDeletion := true
+
 	cm := v1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ConfigMap",
@@ -208,7 +586,7 @@ func (action *startAction) newTestingConfigMap(ctx context.Context, test *v1alph
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: test.Namespace,
-			Name:      TestResourceNameFor(test),
+			Name:      name,
 			Labels: map[string]string{
 				"org.citrusframework.yaks/app":     "yaks",
 				"org.citrusframework.yaks/test":    test.Name,
@@ -225,7 +603,7 @@ func (action *startAction) newTestingConfigMap(ctx context.Context, test *v1alph
 				},
 			},
 		},
-		Data: sources,
+		Data: make(map[string]string),
 	}
 	return &cm
 }
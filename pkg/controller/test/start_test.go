@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"testing"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestMountPathFor(t *testing.T) {
+	assert.Equal(t, "/etc/yaks/secrets/keystore", mountPathFor(v1alpha1.MountSpec{Name: "keystore"}, secretsPath))
+	assert.Equal(t, "/opt/keystore", mountPathFor(v1alpha1.MountSpec{Name: "keystore", Path: "/opt/keystore"}, secretsPath))
+	assert.Equal(t, "/etc/yaks/configmaps/data", mountPathFor(v1alpha1.MountSpec{Name: "data"}, configMapsPath))
+}
+
+func TestMountSecretsAndConfigMaps(t *testing.T) {
+	test := newTest()
+	test.Spec.Secrets = []v1alpha1.MountSpec{{Name: "keystore"}, {Name: "credentials", Path: "/opt/credentials"}}
+	test.Spec.ConfigMaps = []v1alpha1.MountSpec{{Name: "data"}}
+
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "test"}},
+		},
+	}
+	mountSecretsAndConfigMaps(pod, test)
+
+	assert.Len(t, pod.Spec.Volumes, 3)
+	assert.Equal(t, "yaks-secret-0", pod.Spec.Volumes[0].Name)
+	assert.Equal(t, "keystore", pod.Spec.Volumes[0].Secret.SecretName)
+	assert.Equal(t, "yaks-secret-1", pod.Spec.Volumes[1].Name)
+	assert.Equal(t, "credentials", pod.Spec.Volumes[1].Secret.SecretName)
+	assert.Equal(t, "yaks-configmap-0", pod.Spec.Volumes[2].Name)
+	assert.Equal(t, "data", pod.Spec.Volumes[2].ConfigMap.Name)
+
+	assert.Equal(t, []v1.VolumeMount{
+		{Name: "yaks-secret-0", MountPath: "/etc/yaks/secrets/keystore", ReadOnly: true},
+		{Name: "yaks-secret-1", MountPath: "/opt/credentials", ReadOnly: true},
+		{Name: "yaks-configmap-0", MountPath: "/etc/yaks/configmaps/data", ReadOnly: true},
+	}, pod.Spec.Containers[0].VolumeMounts)
+}
@@ -2,6 +2,8 @@ package test
 
 import (
 	"context"
+	"time"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -9,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -97,6 +100,42 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for tests that other tests depend on, so that the waiting tests get started or skipped
+	err = c.Watch(&source.Kind{Type: &v1alpha1.Test{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+			test := a.Object.(*v1alpha1.Test)
+			var requests []reconcile.Request
+
+			list := v1alpha1.TestList{}
+			if err := mgr.GetClient().List(context.TODO(), &list, k8sclient.InNamespace(test.Namespace)); err != nil {
+				Log.Error(err, "Failed to list tests depending on test", "name", test.Name)
+				return requests
+			}
+
+			for _, item := range list.Items {
+				if item.Status.Phase == v1alpha1.TestPhasePending && dependsOn(&item, test.Name) {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: types.NamespacedName{
+							Namespace: item.Namespace,
+							Name:      item.Name,
+						},
+					})
+				}
+			}
+
+			return requests
+		}),
+	}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldTest := e.ObjectOld.(*v1alpha1.Test)
+			newTest := e.ObjectNew.(*v1alpha1.Test)
+			return oldTest.Status.Phase != newTest.Status.Phase
+		},
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -184,6 +223,10 @@ func (r *ReconcileIntegrationTest) Reconcile(request reconcile.Request) (reconci
 		}
 	}
 
+	if delay := retryDelay(target, time.Now()); delay > 0 {
+		return reconcile.Result{RequeueAfter: delay}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -32,3 +32,15 @@ func TestPodNameFor(test *v1alpha1.Test) string {
 func TestResourceNameFor(test *v1alpha1.Test) string {
 	return fmt.Sprintf("test-%s", test.Name)
 }
+
+// MavenSettingsNameFor returns the name of the config map holding the generated Maven settings. The prefix differs
+// from the one of TestResourceNameFor so that the name never matches the resources of another test.
+func MavenSettingsNameFor(test *v1alpha1.Test) string {
+	return fmt.Sprintf("yaks-maven-settings-%s", test.Name)
+}
+
+// TestResourcePartNameFor returns the name of the config map holding the given part of testing resources that do not
+// fit into the first config map
+func TestResourcePartNameFor(test *v1alpha1.Test, part int) string {
+	return fmt.Sprintf("yaks-resources-%s-%d", test.Name, part)
+}
@@ -0,0 +1,353 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/config"
+	"github.com/citrusframework/yaks/pkg/util/maven"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// SetDefaults fills in the default values of the test spec
+func SetDefaults(test *v1alpha1.Test) {
+	if test.Spec.Source.Language == "" {
+		test.Spec.Source.Language = languageFor(test.Spec.Source.Name)
+	}
+
+	for i := range test.Spec.Sources {
+		if test.Spec.Sources[i].Language == "" {
+			test.Spec.Sources[i].Language = languageFor(test.Spec.Sources[i].Name)
+		}
+	}
+
+	if test.Spec.Timeout == "" {
+		test.Spec.Timeout = config.GetTestTimeout()
+	}
+}
+
+// languageFor derives the test language from the file extension and falls back to Gherkin
+func languageFor(name string) v1alpha1.Language {
+	extension := v1alpha1.Language(strings.TrimPrefix(path.Ext(name), "."))
+	for _, language := range v1alpha1.TestLanguages {
+		if language == extension {
+			return language
+		}
+	}
+	return v1alpha1.LanguageGherkin
+}
+
+// TimeoutFor returns the maximum duration of the test run
+func TimeoutFor(test *v1alpha1.Test) (time.Duration, error) {
+	timeout := test.Spec.Timeout
+	if timeout == "" {
+		timeout = config.GetTestTimeout()
+	}
+
+	duration, err := time.ParseDuration(timeout)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid test timeout '%s', expected a positive duration such as 30m", timeout)
+	}
+	return duration, nil
+}
+
+// Validate checks the test spec and returns the first problem found
+func Validate(test *v1alpha1.Test) error {
+	validations := []func(*v1alpha1.Test) error{
+		validateSources,
+		validateTestFiles,
+		validateSettings,
+		validateEnv,
+		validateMounts,
+		validateDependencies,
+		validateDependsOn,
+		validateRetries,
+		func(test *v1alpha1.Test) error {
+			return validateRuntimeSpec(test.Spec.Runtime)
+		},
+		func(test *v1alpha1.Test) error {
+			_, err := TimeoutFor(test)
+			return err
+		},
+	}
+
+	for _, validate := range validations {
+		if err := validate(test); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateSources makes sure that the test sources are not empty and use a supported language
+func validateSources(test *v1alpha1.Test) error {
+	sources := append([]v1alpha1.SourceSpec{test.Spec.Source}, test.Spec.Sources...)
+	for _, source := range sources {
+		if strings.TrimSpace(source.Content) == "" && len(source.Parts) == 0 {
+			return fmt.Errorf("test source '%s' must not be empty", source.Name)
+		}
+
+		if source.Language != "" && !isSupportedLanguage(source.Language) {
+			return fmt.Errorf("unsupported language '%s' of test source '%s', supported languages are %v",
+				source.Language, source.Name, v1alpha1.TestLanguages)
+		}
+	}
+
+	return nil
+}
+
+func isSupportedLanguage(language v1alpha1.Language) bool {
+	for _, l := range v1alpha1.TestLanguages {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSettings makes sure that the runtime settings file can be parsed and holds valid dependencies
+func validateSettings(test *v1alpha1.Test) error {
+	if test.Spec.Settings.Name == "" {
+		return nil
+	}
+	if len(test.Spec.Settings.Parts) > 0 {
+		// the content is not available to the operator, the settings get loaded by the test runtime
+		return nil
+	}
+
+	dependencies, err := maven.LoadSettingsDependencies(test.Spec.Settings.Name, test.Spec.Settings.Content)
+	if err != nil {
+		return err
+	}
+
+	for _, dependency := range dependencies {
+		if err := maven.ValidateDependency(dependency); err != nil {
+			return fmt.Errorf("invalid settings file %s: %v", test.Spec.Settings.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateTestFiles makes sure that all sources and resources use unique paths relative to the tests path
+func validateTestFiles(test *v1alpha1.Test) error {
+	paths := make(map[string]bool)
+	names := []string{test.Spec.Source.Name}
+	if test.Spec.Settings.Name != "" {
+		names = append(names, test.Spec.Settings.Name)
+	}
+	for _, source := range test.Spec.Sources {
+		names = append(names, source.Name)
+	}
+	for _, resource := range test.Spec.Resources {
+		names = append(names, resource.Name)
+	}
+
+	for _, name := range names {
+		if name == "" || path.IsAbs(name) || path.Clean(name) != name || strings.HasPrefix(name, "..") {
+			return fmt.Errorf("invalid test file name '%s', expected a path relative to the tests path", name)
+		}
+
+		if paths[name] {
+			return fmt.Errorf("duplicate test file '%s'", name)
+		}
+		paths[name] = true
+	}
+
+	for _, resource := range test.Spec.Resources {
+		if resource.ConfigMap != nil && resource.Secret != nil {
+			return fmt.Errorf("test resource '%s' must not reference both a config map and a secret", resource.Name)
+		}
+		if resource.ConfigMap != nil && (resource.ConfigMap.Name == "" || resource.ConfigMap.Key == "") {
+			return fmt.Errorf("test resource '%s' must reference a config map name and key", resource.Name)
+		}
+		if resource.Secret != nil && (resource.Secret.Name == "" || resource.Secret.Key == "") {
+			return fmt.Errorf("test resource '%s' must reference a secret name and key", resource.Name)
+		}
+		if len(resource.Parts) > 0 && (resource.ConfigMap != nil || resource.Secret != nil) {
+			return fmt.Errorf("test resource '%s' must not reference both parts and a config map or secret", resource.Name)
+		}
+	}
+
+	for _, file := range partedFilesFor(test) {
+		for _, part := range file.Parts {
+			if part.Name == "" || part.Key == "" {
+				return fmt.Errorf("parts of test file '%s' must reference a config map name and key", file.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateEnv makes sure that environment variables are given as KEY=VALUE pairs or reference a single config map or secret key
+func validateEnv(test *v1alpha1.Test) error {
+	for _, value := range test.Spec.Env {
+		pair := strings.SplitN(value, "=", 2)
+		if len(pair) != 2 || strings.TrimSpace(pair[0]) == "" {
+			return fmt.Errorf("malformed environment variable '%s', expected KEY=VALUE", value)
+		}
+	}
+
+	for _, value := range test.Spec.EnvVars {
+		if value.Name == "" {
+			return fmt.Errorf("missing name of environment variable")
+		}
+		if value.ValueFrom != nil {
+			if value.Value != "" {
+				return fmt.Errorf("environment variable '%s' must not define both a value and a value source", value.Name)
+			}
+			if value.ValueFrom.SecretKeyRef != nil && value.ValueFrom.ConfigMapKeyRef != nil {
+				return fmt.Errorf("environment variable '%s' must not reference both a config map and a secret", value.Name)
+			}
+		}
+	}
+
+	for _, source := range test.Spec.EnvFrom {
+		if (source.SecretRef == nil) == (source.ConfigMapRef == nil) {
+			return fmt.Errorf("environment source must reference either a config map or a secret")
+		}
+	}
+
+	return nil
+}
+
+// validateMounts makes sure that the secrets and config maps listed in the test use unique mount paths
+// that do not clash with the paths used by the test runtime
+func validateMounts(test *v1alpha1.Test) error {
+	reserved := []string{testsPath, mavenSettingsPath, mavenCachePath, mavenOverlayPath}
+	paths := make(map[string]bool)
+	for _, mount := range test.Spec.Runtime.Pod.VolumeMounts {
+		paths[path.Clean(mount.MountPath)] = true
+	}
+
+	check := func(mount v1alpha1.MountSpec, parent string) error {
+		if mount.Name == "" {
+			return fmt.Errorf("missing name of mounted secret or config map")
+		}
+
+		mountPath := mountPathFor(mount, parent)
+		if !path.IsAbs(mountPath) || path.Clean(mountPath) != mountPath || mountPath == "/" {
+			return fmt.Errorf("invalid mount path '%s' of '%s', expected an absolute path", mountPath, mount.Name)
+		}
+		for _, p := range reserved {
+			if mountPath == p || strings.HasPrefix(mountPath, p+"/") || strings.HasPrefix(p, mountPath+"/") {
+				return fmt.Errorf("mount path '%s' of '%s' clashes with the reserved path '%s'", mountPath, mount.Name, p)
+			}
+		}
+		if paths[mountPath] {
+			return fmt.Errorf("duplicate mount path '%s'", mountPath)
+		}
+		paths[mountPath] = true
+		return nil
+	}
+
+	for _, secret := range test.Spec.Secrets {
+		if err := check(secret, secretsPath); err != nil {
+			return err
+		}
+	}
+	for _, cm := range test.Spec.ConfigMaps {
+		if err := check(cm, configMapsPath); err != nil {
+			return err
+		}
+	}
+
+	for _, volume := range test.Spec.Runtime.Pod.Volumes {
+		if strings.HasPrefix(volume.Name, "yaks-secret-") || strings.HasPrefix(volume.Name, "yaks-configmap-") {
+			return fmt.Errorf("volume name '%s' is reserved for mounted secrets and config maps", volume.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateDependencies checks the Maven coordinates of the test dependencies and the repository settings
+func validateDependencies(test *v1alpha1.Test) error {
+	if _, err := maven.MergeDependencies(nil, test.Spec.Dependencies...); err != nil {
+		return err
+	}
+
+	for _, repository := range test.Spec.Repositories {
+		if err := maven.ValidateRepository(repository); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateDependsOn makes sure that the test depends on other tests by valid and unique names
+func validateDependsOn(test *v1alpha1.Test) error {
+	names := make(map[string]bool)
+	for _, name := range test.Spec.DependsOn {
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return fmt.Errorf("invalid name '%s' of test dependency: %s", name, strings.Join(errs, ", "))
+		}
+		if name == test.Name {
+			return fmt.Errorf("test %s must not depend on itself", test.Name)
+		}
+		if names[name] {
+			return fmt.Errorf("duplicate test dependency '%s'", name)
+		}
+		names[name] = true
+	}
+
+	return nil
+}
+
+// validateRetries makes sure that the number of retries and the retry backoff are valid
+func validateRetries(test *v1alpha1.Test) error {
+	if test.Spec.Retries < 0 {
+		return fmt.Errorf("invalid number of retries %d, expected a positive number", test.Spec.Retries)
+	}
+
+	_, err := RetryBackoffFor(test, 1)
+	return err
+}
+
+// validateRuntimeSpec makes sure that the runtime settings are valid and that the pod customizations
+// do not clash with the volumes used by the testing pod
+func validateRuntimeSpec(spec v1alpha1.RuntimeSpec) error {
+	switch spec.ImagePullPolicy {
+	case "", v1.PullAlways, v1.PullIfNotPresent, v1.PullNever:
+	default:
+		return fmt.Errorf("unsupported image pull policy '%s'", spec.ImagePullPolicy)
+	}
+
+	for _, volume := range spec.Pod.Volumes {
+		if volume.Name == testsVolume || volume.Name == testSourcesVolume || volume.Name == testPartsVolume || volume.Name == mavenSettingsVolume || volume.Name == mavenCacheVolume || volume.Name == mavenOverlayVolume {
+			return fmt.Errorf("volume name '%s' is reserved for the test runtime", volume.Name)
+		}
+	}
+
+	for _, mount := range spec.Pod.VolumeMounts {
+		if mount.MountPath == testsPath || mount.MountPath == mavenSettingsPath || mount.MountPath == mavenCachePath || mount.MountPath == mavenOverlayPath {
+			return fmt.Errorf("mount path '%s' is reserved for the test runtime", mount.MountPath)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"testing"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func newTest() *v1alpha1.Test {
+	return &v1alpha1.Test{
+		Spec: v1alpha1.TestSpec{
+			Source: v1alpha1.SourceSpec{
+				Name:    "hello.feature",
+				Content: "Feature: Hello",
+			},
+		},
+	}
+}
+
+func TestSetDefaults(t *testing.T) {
+	test := newTest()
+	SetDefaults(test)
+
+	assert.Equal(t, v1alpha1.LanguageGherkin, test.Spec.Source.Language)
+	assert.Equal(t, "30m", test.Spec.Timeout)
+
+	test.Spec.Timeout = "1h"
+	SetDefaults(test)
+	assert.Equal(t, "1h", test.Spec.Timeout)
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Validate(newTest()))
+
+	test := newTest()
+	test.Spec.Source.Content = "  "
+	assert.EqualError(t, Validate(test), "test source 'hello.feature' must not be empty")
+
+	test = newTest()
+	test.Spec.Source.Language = "groovy"
+	assert.NotNil(t, Validate(test))
+
+	test = newTest()
+	test.Spec.Env = []string{"LOG_LEVEL=debug", "EMPTY=", "MALFORMED"}
+	assert.EqualError(t, Validate(test), "malformed environment variable 'MALFORMED', expected KEY=VALUE")
+
+	test = newTest()
+	test.Spec.Settings = v1alpha1.SettingsSpec{Name: "yaks.settings.json", Content: "{ dependencies: "}
+	assert.NotNil(t, Validate(test))
+
+	test = newTest()
+	test.Spec.Settings = v1alpha1.SettingsSpec{Name: "yaks.settings.yaml", Content: "dependencies:\n- dependency:\n    groupId: org.foo\n"}
+	assert.NotNil(t, Validate(test))
+
+	test = newTest()
+	test.Spec.Timeout = "soon"
+	assert.NotNil(t, Validate(test))
+
+	test = newTest()
+	test.Spec.Retries = -1
+	assert.NotNil(t, Validate(test))
+
+	test = newTest()
+	test.Spec.RetryBackoff = "later"
+	assert.NotNil(t, Validate(test))
+
+	test = newTest()
+	test.Spec.Secrets = []v1alpha1.MountSpec{{Name: "keystore", Path: "/etc/yaks/tests/keystore"}}
+	assert.NotNil(t, Validate(test))
+
+	test = newTest()
+	test.Name = "consumer"
+	test.Spec.DependsOn = []string{"provisioning"}
+	assert.Nil(t, Validate(test))
+
+	test.Spec.DependsOn = []string{"provisioning", "consumer"}
+	assert.EqualError(t, Validate(test), "test consumer must not depend on itself")
+
+	test.Spec.DependsOn = []string{"provisioning", "provisioning"}
+	assert.EqualError(t, Validate(test), "duplicate test dependency 'provisioning'")
+}
+
+func TestValidateMounts(t *testing.T) {
+	test := newTest()
+	test.Spec.Secrets = []v1alpha1.MountSpec{{Name: "keystore"}, {Name: "credentials", Path: "/opt/credentials"}}
+	test.Spec.ConfigMaps = []v1alpha1.MountSpec{{Name: "keystore"}}
+	assert.Nil(t, validateMounts(test))
+
+	test = newTest()
+	test.Spec.Secrets = []v1alpha1.MountSpec{{Path: "/opt/credentials"}}
+	assert.EqualError(t, validateMounts(test), "missing name of mounted secret or config map")
+
+	test = newTest()
+	test.Spec.Secrets = []v1alpha1.MountSpec{{Name: "credentials", Path: "opt/credentials"}}
+	assert.EqualError(t, validateMounts(test), "invalid mount path 'opt/credentials' of 'credentials', expected an absolute path")
+
+	test = newTest()
+	test.Spec.ConfigMaps = []v1alpha1.MountSpec{{Name: "data", Path: "/etc/yaks"}}
+	assert.EqualError(t, validateMounts(test), "mount path '/etc/yaks' of 'data' clashes with the reserved path '/etc/yaks/tests'")
+
+	test = newTest()
+	test.Spec.Secrets = []v1alpha1.MountSpec{{Name: "credentials", Path: "/opt/data"}}
+	test.Spec.ConfigMaps = []v1alpha1.MountSpec{{Name: "data", Path: "/opt/data"}}
+	assert.EqualError(t, validateMounts(test), "duplicate mount path '/opt/data'")
+
+	test = newTest()
+	test.Spec.Secrets = []v1alpha1.MountSpec{{Name: "credentials", Path: "/opt/data"}}
+	test.Spec.Runtime.Pod.VolumeMounts = []v1.VolumeMount{{Name: "data", MountPath: "/opt/data/"}}
+	assert.EqualError(t, validateMounts(test), "duplicate mount path '/opt/data'")
+
+	test = newTest()
+	test.Spec.Runtime.Pod.Volumes = []v1.Volume{{Name: "yaks-secret-0"}}
+	assert.EqualError(t, validateMounts(test), "volume name 'yaks-secret-0' is reserved for mounted secrets and config maps")
+}
+
+func TestValidateRuntimeSpec(t *testing.T) {
+	assert.Nil(t, validateRuntimeSpec(v1alpha1.RuntimeSpec{Image: "yaks-runtime:1.0", ImagePullPolicy: v1.PullNever}))
+	assert.EqualError(t, validateRuntimeSpec(v1alpha1.RuntimeSpec{ImagePullPolicy: "Sometimes"}), "unsupported image pull policy 'Sometimes'")
+
+	spec := v1alpha1.RuntimeSpec{}
+	spec.Pod.Volumes = []v1.Volume{{Name: testsVolume}}
+	assert.EqualError(t, validateRuntimeSpec(spec), "volume name 'tests' is reserved for the test runtime")
+
+	spec = v1alpha1.RuntimeSpec{}
+	spec.Pod.VolumeMounts = []v1.VolumeMount{{Name: "data", MountPath: testsPath}}
+	assert.EqualError(t, validateRuntimeSpec(spec), "mount path '/etc/yaks/tests' is reserved for the test runtime")
+}
@@ -0,0 +1,187 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testschedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewScheduleAction creates a new schedule action
+func NewScheduleAction() Action {
+	return &scheduleAction{}
+}
+
+type scheduleAction struct {
+	baseAction
+}
+
+// Name returns a common name of the action
+func (action *scheduleAction) Name() string {
+	return "schedule"
+}
+
+// CanHandle tells whether this action can handle the test schedule
+func (action *scheduleAction) CanHandle(schedule *v1alpha1.TestSchedule) bool {
+	return true
+}
+
+// Handle updates the state of previous runs and creates a new test run if one is due
+func (action *scheduleAction) Handle(ctx context.Context, schedule *v1alpha1.TestSchedule) (*v1alpha1.TestSchedule, error) {
+	sched, err := Validate(schedule)
+	if err != nil {
+		schedule.Status.Errors = v1alpha1.TestErrors{{Test: schedule.Name, Message: err.Error()}}
+		return schedule, nil
+	}
+	schedule.Status.Errors = nil
+
+	tests, err := action.listRuns(ctx, schedule)
+	if err != nil {
+		return nil, err
+	}
+	sortByScheduledTime(tests)
+	updateStatus(schedule, tests)
+
+	for _, test := range historyToDelete(schedule, tests) {
+		if err := action.client.Delete(ctx, test); err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		action.L.Infof("Removed test run %s of schedule %s", test.Name, schedule.Name)
+	}
+
+	earliest := schedule.CreationTimestamp.Time
+	if schedule.Status.LastScheduleTime != nil {
+		earliest = schedule.Status.LastScheduleTime.Time
+	}
+	scheduled := mostRecentRun(sched, earliest.UTC(), time.Now().UTC())
+	if scheduled.IsZero() {
+		return schedule, nil
+	}
+
+	switch ConcurrencyPolicyFor(schedule) {
+	case v1alpha1.TestConcurrencyForbid:
+		if len(schedule.Status.Active) > 0 {
+			action.L.Infof("Skipping run of schedule %s at %s as previous run is still active", schedule.Name, scheduled)
+			schedule.Status.LastScheduleTime = timeRef(scheduled)
+			return schedule, nil
+		}
+	case v1alpha1.TestConcurrencyReplace:
+		for i := range tests {
+			if isFinished(tests[i].Status.Phase) {
+				continue
+			}
+			if err := action.client.Delete(ctx, &tests[i]); err != nil && !k8serrors.IsNotFound(err) {
+				return nil, err
+			}
+			action.L.Infof("Replaced active test run %s of schedule %s", tests[i].Name, schedule.Name)
+		}
+		schedule.Status.Active = nil
+	}
+
+	name, err := action.createRun(ctx, schedule, scheduled)
+	if err != nil {
+		return nil, err
+	}
+	action.L.Infof("Created test run %s of schedule %s", name, schedule.Name)
+
+	schedule.Status.LastScheduleTime = timeRef(scheduled)
+	schedule.Status.LastRun = name
+	schedule.Status.Active = append(schedule.Status.Active, name)
+
+	return schedule, nil
+}
+
+// listRuns returns the tests created by the schedule
+func (action *scheduleAction) listRuns(ctx context.Context, schedule *v1alpha1.TestSchedule) ([]v1alpha1.Test, error) {
+	list := v1alpha1.TestList{}
+	if err := action.client.List(ctx, &list, client.InNamespace(schedule.Namespace), client.MatchingLabels{
+		v1alpha1.TestScheduleLabel: schedule.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	tests := make([]v1alpha1.Test, 0, len(list.Items))
+	for _, test := range list.Items {
+		if owner := metav1.GetControllerOf(&test); owner != nil && owner.UID == schedule.UID {
+			tests = append(tests, test)
+		}
+	}
+	return tests, nil
+}
+
+// createRun creates the test for the run scheduled at the given time. The schedule owns the test so it gets removed
+// with the schedule.
+func (action *scheduleAction) createRun(ctx context.Context, schedule *v1alpha1.TestSchedule, scheduled time.Time) (string, error) {
+	name := TestNameFor(schedule, scheduled)
+
+	labels := make(map[string]string)
+	for k, v := range schedule.Spec.Template.Labels {
+		labels[k] = v
+	}
+	labels[v1alpha1.TestScheduleLabel] = schedule.Name
+
+	controller := true
+	blockOwnerDeletion := true
+	test := v1alpha1.Test{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha1.TestKind,
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: schedule.Namespace,
+			Name:      name,
+			Labels:    labels,
+			Annotations: map[string]string{
+				v1alpha1.TestScheduledTimeAnnotation: scheduled.Format(time.RFC3339),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         v1alpha1.SchemeGroupVersion.String(),
+					Kind:               v1alpha1.TestScheduleKind,
+					Name:               schedule.Name,
+					UID:                schedule.UID,
+					Controller:         &controller,
+					BlockOwnerDeletion: &blockOwnerDeletion,
+				},
+			},
+		},
+		Spec: *schedule.Spec.Template.Spec.DeepCopy(),
+	}
+
+	err := action.client.Create(ctx, &test)
+	if err != nil && k8serrors.IsAlreadyExists(err) {
+		// the run may have been created by a previous reconcile whose status update got lost
+		existing := v1alpha1.Test{}
+		if err := action.client.Get(ctx, client.ObjectKey{Namespace: schedule.Namespace, Name: name}, &existing); err != nil {
+			return "", err
+		}
+		if owner := metav1.GetControllerOf(&existing); owner == nil || owner.UID != schedule.UID {
+			return "", fmt.Errorf("test %s already exists and does not belong to schedule %s", name, schedule.Name)
+		}
+	} else if err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
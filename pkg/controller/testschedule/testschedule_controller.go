@@ -0,0 +1,193 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testschedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/client"
+	"github.com/citrusframework/yaks/pkg/util/log"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Add creates a new TestSchedule Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	c, err := client.FromManager(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(c, mgr.GetConfig()))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(c client.Client, cfg *rest.Config) reconcile.Reconciler {
+	return &ReconcileTestSchedule{
+		client: c,
+		config: cfg,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("testschedule-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource TestSchedule
+	err = c.Watch(&source.Kind{Type: &v1alpha1.TestSchedule{}}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSchedule := e.ObjectOld.(*v1alpha1.TestSchedule)
+			newSchedule := e.ObjectNew.(*v1alpha1.TestSchedule)
+			// Ignore updates to the schedule status in which case metadata.Generation does not change
+			return oldSchedule.Generation != newSchedule.Generation
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			// Evaluates to false if the object has been confirmed deleted
+			return !e.DeleteStateUnknown
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the test runs created by a schedule
+	err = c.Watch(&source.Kind{Type: &v1alpha1.Test{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &v1alpha1.TestSchedule{},
+	}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldTest := e.ObjectOld.(*v1alpha1.Test)
+			newTest := e.ObjectNew.(*v1alpha1.Test)
+			return oldTest.Status.Phase != newTest.Status.Phase
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileTestSchedule{}
+
+// ReconcileTestSchedule reconciles a TestSchedule object
+type ReconcileTestSchedule struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client client.Client
+	config *rest.Config
+}
+
+// Reconcile reads that state of the cluster for a TestSchedule object, creates the test runs that are due and
+// requeues the schedule until its next run
+func (r *ReconcileTestSchedule) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	rlog := Log.WithValues("request-namespace", request.Namespace, "request-name", request.Name)
+	rlog.Info("Reconciling TestSchedule")
+
+	ctx := context.TODO()
+
+	var instance v1alpha1.TestSchedule
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Owned tests are automatically garbage collected
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if instance.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	target := instance.DeepCopy()
+	targetLog := rlog.ForTestSchedule(target)
+
+	actions := []Action{
+		NewScheduleAction(),
+	}
+
+	for _, a := range actions {
+		a.InjectClient(r.client)
+		a.InjectConfig(r.config)
+		a.InjectLogger(targetLog)
+
+		if a.CanHandle(target) {
+			targetLog.Infof("Invoking action %s", a.Name())
+
+			newTarget, err := a.Handle(ctx, target)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+
+			if newTarget != nil {
+				if r, err := r.update(ctx, targetLog, newTarget); err != nil || r.Requeue {
+					return r, err
+				}
+			}
+
+			// handle one action at time so the resource
+			// is always at its latest state
+			break
+		}
+	}
+
+	return requeueForNextRun(target, time.Now().UTC()), nil
+}
+
+func (r *ReconcileTestSchedule) update(ctx context.Context, log log.Logger, target *v1alpha1.TestSchedule) (reconcile.Result, error) {
+	err := r.client.Status().Update(ctx, target)
+	if err != nil {
+		if k8serrors.IsConflict(err) {
+			log.Error(err, "conflict")
+
+			return reconcile.Result{
+				Requeue: true,
+			}, nil
+		}
+	}
+
+	return reconcile.Result{}, err
+}
+
+// requeueForNextRun returns a result that triggers the reconciliation again when the next run is due
+func requeueForNextRun(schedule *v1alpha1.TestSchedule, now time.Time) reconcile.Result {
+	sched, err := Validate(schedule)
+	if err != nil {
+		// wait for the schedule to be fixed
+		return reconcile.Result{}
+	}
+
+	next := sched.Next(now)
+	if next.IsZero() {
+		return reconcile.Result{}
+	}
+	// the next run time has minute precision so a small delay makes sure it is due when reconciling
+	return reconcile.Result{RequeueAfter: next.Sub(now) + time.Second}
+}
@@ -0,0 +1,164 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testschedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/util/cron"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	defaultSuccessfulHistoryLimit = 3
+	defaultFailedHistoryLimit     = 1
+)
+
+// ConcurrencyPolicyFor returns the concurrency policy of the schedule, concurrent runs are allowed by default
+func ConcurrencyPolicyFor(schedule *v1alpha1.TestSchedule) v1alpha1.TestConcurrencyPolicy {
+	if schedule.Spec.ConcurrencyPolicy == "" {
+		return v1alpha1.TestConcurrencyAllow
+	}
+	return schedule.Spec.ConcurrencyPolicy
+}
+
+// TestNameFor returns the name of the test run scheduled at the given time. The name is unique per scheduled time
+// so that a run never gets created twice.
+func TestNameFor(schedule *v1alpha1.TestSchedule, scheduled time.Time) string {
+	return fmt.Sprintf("%s-%d", schedule.Name, scheduled.Unix()/60)
+}
+
+// Validate checks the spec of the schedule and returns the parsed cron schedule
+func Validate(schedule *v1alpha1.TestSchedule) (*cron.Schedule, error) {
+	sched, err := cron.Parse(schedule.Spec.Schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := ConcurrencyPolicyFor(schedule)
+	supported := false
+	for _, p := range v1alpha1.TestConcurrencyPolicies {
+		if p == policy {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, fmt.Errorf("unsupported concurrency policy '%s', expected one of %v", policy, v1alpha1.TestConcurrencyPolicies)
+	}
+
+	if errs := validation.IsDNS1123Label(TestNameFor(schedule, time.Now())); len(errs) > 0 {
+		return nil, fmt.Errorf("schedule name %s is too long or invalid: %s", schedule.Name, strings.Join(errs, ", "))
+	}
+
+	return sched, nil
+}
+
+// mostRecentRun returns the latest time a run was due after the given earliest time and not later than now. A zero
+// time is returned if no run is due.
+func mostRecentRun(sched *cron.Schedule, earliest time.Time, now time.Time) time.Time {
+	var recent time.Time
+	for t := sched.Next(earliest); !t.IsZero() && !t.After(now); t = sched.Next(t) {
+		recent = t
+	}
+	return recent
+}
+
+// scheduledTimeFor returns the time the given test run was due
+func scheduledTimeFor(test *v1alpha1.Test) time.Time {
+	if value, ok := test.Annotations[v1alpha1.TestScheduledTimeAnnotation]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t
+		}
+	}
+	return test.CreationTimestamp.Time
+}
+
+func isFinished(phase v1alpha1.TestPhase) bool {
+	return phase == v1alpha1.TestPhasePassed ||
+		phase == v1alpha1.TestPhaseFailed ||
+		phase == v1alpha1.TestPhaseError ||
+		phase == v1alpha1.TestPhaseSkipped
+}
+
+// sortByScheduledTime sorts the test runs from oldest to latest
+func sortByScheduledTime(tests []v1alpha1.Test) {
+	sort.SliceStable(tests, func(i, j int) bool {
+		return scheduledTimeFor(&tests[i]).Before(scheduledTimeFor(&tests[j]))
+	})
+}
+
+// updateStatus sets the active runs and the result of the latest finished run. Tests must be sorted by scheduled time.
+func updateStatus(schedule *v1alpha1.TestSchedule, tests []v1alpha1.Test) {
+	schedule.Status.Active = nil
+	for i := range tests {
+		test := &tests[i]
+		if !isFinished(test.Status.Phase) {
+			schedule.Status.Active = append(schedule.Status.Active, test.Name)
+			continue
+		}
+
+		scheduled := scheduledTimeFor(test)
+		schedule.Status.LastResult = test.Status.Phase
+		if test.Status.Phase == v1alpha1.TestPhasePassed {
+			if last := schedule.Status.LastSuccessfulTime; last == nil || last.Time.Before(scheduled) {
+				schedule.Status.LastSuccessfulTime = timeRef(scheduled)
+			}
+		}
+	}
+}
+
+// historyToDelete returns the finished runs that exceed the history limits. Tests must be sorted by scheduled time.
+func historyToDelete(schedule *v1alpha1.TestSchedule, tests []v1alpha1.Test) []*v1alpha1.Test {
+	successful := make([]*v1alpha1.Test, 0)
+	failed := make([]*v1alpha1.Test, 0)
+	for i := range tests {
+		switch tests[i].Status.Phase {
+		case v1alpha1.TestPhasePassed:
+			successful = append(successful, &tests[i])
+		case v1alpha1.TestPhaseFailed, v1alpha1.TestPhaseError, v1alpha1.TestPhaseSkipped:
+			failed = append(failed, &tests[i])
+		}
+	}
+
+	remove := make([]*v1alpha1.Test, 0)
+	remove = append(remove, exceeding(successful, schedule.Spec.SuccessfulHistoryLimit, defaultSuccessfulHistoryLimit)...)
+	remove = append(remove, exceeding(failed, schedule.Spec.FailedHistoryLimit, defaultFailedHistoryLimit)...)
+	return remove
+}
+
+func exceeding(tests []*v1alpha1.Test, limit *int32, defaultLimit int) []*v1alpha1.Test {
+	keep := defaultLimit
+	if limit != nil {
+		keep = int(*limit)
+	}
+	if keep < 0 || len(tests) <= keep {
+		return nil
+	}
+	return tests[:len(tests)-keep]
+}
+
+func timeRef(t time.Time) *metav1.Time {
+	ref := metav1.NewTime(t)
+	return &ref
+}
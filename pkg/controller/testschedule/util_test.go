@@ -0,0 +1,132 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/util/cron"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var start = time.Date(2020, 3, 10, 10, 0, 0, 0, time.UTC)
+
+func newRun(minutes int, phase v1alpha1.TestPhase) v1alpha1.Test {
+	scheduled := start.Add(time.Duration(minutes) * time.Minute)
+	return v1alpha1.Test{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: scheduled.Format("1504"),
+			Annotations: map[string]string{
+				v1alpha1.TestScheduledTimeAnnotation: scheduled.Format(time.RFC3339),
+			},
+		},
+		Status: v1alpha1.TestStatus{Phase: phase},
+	}
+}
+
+func names(tests []*v1alpha1.Test) []string {
+	result := make([]string, 0, len(tests))
+	for _, test := range tests {
+		result = append(result, test.Name)
+	}
+	return result
+}
+
+func TestMostRecentRun(t *testing.T) {
+	sched, err := cron.Parse("*/10 * * * *")
+	assert.Nil(t, err)
+
+	assert.True(t, mostRecentRun(sched, start, start.Add(5*time.Minute)).IsZero())
+	assert.Equal(t, start.Add(10*time.Minute), mostRecentRun(sched, start, start.Add(10*time.Minute)))
+	// missed runs are not caught up, only the latest run is due
+	assert.Equal(t, start.Add(30*time.Minute), mostRecentRun(sched, start, start.Add(35*time.Minute)))
+}
+
+func TestUpdateStatus(t *testing.T) {
+	schedule := v1alpha1.TestSchedule{}
+	tests := []v1alpha1.Test{
+		newRun(20, v1alpha1.TestPhaseRunning),
+		newRun(0, v1alpha1.TestPhasePassed),
+		newRun(10, v1alpha1.TestPhaseFailed),
+	}
+	sortByScheduledTime(tests)
+	updateStatus(&schedule, tests)
+
+	assert.Equal(t, []string{"1020"}, schedule.Status.Active)
+	assert.Equal(t, v1alpha1.TestPhaseFailed, schedule.Status.LastResult)
+	assert.Equal(t, start, schedule.Status.LastSuccessfulTime.Time)
+}
+
+func TestHistoryToDelete(t *testing.T) {
+	schedule := v1alpha1.TestSchedule{}
+	tests := []v1alpha1.Test{
+		newRun(0, v1alpha1.TestPhasePassed),
+		newRun(10, v1alpha1.TestPhaseFailed),
+		newRun(20, v1alpha1.TestPhasePassed),
+		newRun(30, v1alpha1.TestPhaseError),
+		newRun(40, v1alpha1.TestPhasePassed),
+		newRun(50, v1alpha1.TestPhasePassed),
+		newRun(60, v1alpha1.TestPhaseRunning),
+	}
+	assert.Equal(t, []string{"1000", "1010"}, names(historyToDelete(&schedule, tests)))
+
+	successful := int32(1)
+	failed := int32(0)
+	schedule.Spec.SuccessfulHistoryLimit = &successful
+	schedule.Spec.FailedHistoryLimit = &failed
+	assert.Equal(t, []string{"1000", "1020", "1040", "1010", "1030"}, names(historyToDelete(&schedule, tests)))
+}
+
+func TestSkippedRuns(t *testing.T) {
+	failed := int32(3)
+	schedule := v1alpha1.TestSchedule{
+		Spec: v1alpha1.TestScheduleSpec{FailedHistoryLimit: &failed},
+	}
+	tests := []v1alpha1.Test{
+		newRun(0, v1alpha1.TestPhaseSkipped),
+		newRun(10, v1alpha1.TestPhaseFailed),
+		newRun(20, v1alpha1.TestPhaseSkipped),
+		newRun(30, v1alpha1.TestPhaseFailed),
+	}
+	updateStatus(&schedule, tests)
+
+	assert.Empty(t, schedule.Status.Active)
+	assert.Equal(t, v1alpha1.TestPhaseFailed, schedule.Status.LastResult)
+	assert.Equal(t, []string{"1000"}, names(historyToDelete(&schedule, tests)))
+}
+
+func TestValidate(t *testing.T) {
+	schedule := v1alpha1.TestSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "smoke"},
+		Spec:       v1alpha1.TestScheduleSpec{Schedule: "@hourly"},
+	}
+	_, err := Validate(&schedule)
+	assert.Nil(t, err)
+
+	schedule.Spec.ConcurrencyPolicy = "Random"
+	_, err = Validate(&schedule)
+	assert.NotNil(t, err)
+
+	schedule.Spec.ConcurrencyPolicy = v1alpha1.TestConcurrencyForbid
+	schedule.Spec.Schedule = "every hour"
+	_, err = Validate(&schedule)
+	assert.NotNil(t, err)
+}
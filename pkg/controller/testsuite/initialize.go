@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testsuite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewInitializeAction creates a new initialize action
+func NewInitializeAction() Action {
+	return &initializeAction{}
+}
+
+type initializeAction struct {
+	baseAction
+}
+
+// Name returns a common name of the action
+func (action *initializeAction) Name() string {
+	return "initialize"
+}
+
+// CanHandle tells whether this action can handle the test suite
+func (action *initializeAction) CanHandle(suite *v1alpha1.TestSuite) bool {
+	return suite.Status.Phase == v1alpha1.TestPhaseNone
+}
+
+// Handle handles the test suite
+func (action *initializeAction) Handle(ctx context.Context, suite *v1alpha1.TestSuite) (*v1alpha1.TestSuite, error) {
+	if err := action.validate(ctx, suite); err != nil {
+		suite.Status.Phase = v1alpha1.TestPhaseError
+		suite.Status.Errors = v1alpha1.TestErrors{{Test: suite.Name, Message: err.Error()}}
+		return suite, nil
+	}
+
+	if err := action.cleanup(ctx, suite); err != nil {
+		return nil, err
+	}
+
+	suite.Status.Tests = make([]v1alpha1.TestSuiteResult, 0, len(suite.Spec.Tests))
+	for _, entry := range suite.Spec.Tests {
+		suite.Status.Tests = append(suite.Status.Tests, v1alpha1.TestSuiteResult{
+			Name: entry.Name,
+			Test: TestNameFor(suite, entry),
+		})
+	}
+	suite.Status.Phase = v1alpha1.TestPhaseRunning
+
+	return suite, nil
+}
+
+func (action *initializeAction) validate(ctx context.Context, suite *v1alpha1.TestSuite) error {
+	if err := Validate(suite); err != nil {
+		return err
+	}
+
+	for _, entry := range suite.Spec.Tests {
+		if entry.Spec != nil {
+			continue
+		}
+
+		ref := v1alpha1.Test{}
+		key := client.ObjectKey{Namespace: suite.Namespace, Name: RefFor(entry)}
+		if err := action.client.Get(ctx, key, &ref); err != nil && k8serrors.IsNotFound(err) {
+			return fmt.Errorf("test '%s' referenced by suite %s does not exist", RefFor(entry), suite.Name)
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cleanup removes the tests of a previous run of the suite, e.g. when the suite has been reset to run again
+func (action *initializeAction) cleanup(ctx context.Context, suite *v1alpha1.TestSuite) error {
+	tests := v1alpha1.TestList{}
+	if err := action.client.List(ctx, &tests, client.InNamespace(suite.Namespace), client.MatchingLabels{
+		v1alpha1.TestSuiteLabel: suite.Name,
+	}); err != nil {
+		return err
+	}
+
+	for i := range tests.Items {
+		test := &tests.Items[i]
+		if owner := metav1.GetControllerOf(test); owner == nil || owner.UID != suite.UID {
+			continue
+		}
+		if err := action.client.Delete(ctx, test); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		action.L.Infof("Removed test %s of a previous run of suite %s", test.Name, suite.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testsuite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewRunAction creates a new run action
+func NewRunAction() Action {
+	return &runAction{}
+}
+
+type runAction struct {
+	baseAction
+}
+
+// Name returns a common name of the action
+func (action *runAction) Name() string {
+	return "run"
+}
+
+// CanHandle tells whether this action can handle the test suite
+func (action *runAction) CanHandle(suite *v1alpha1.TestSuite) bool {
+	return suite.Status.Phase == v1alpha1.TestPhaseRunning
+}
+
+// Handle handles the test suite
+func (action *runAction) Handle(ctx context.Context, suite *v1alpha1.TestSuite) (*v1alpha1.TestSuite, error) {
+	for i := range suite.Status.Tests {
+		if err := action.updateResult(ctx, suite, &suite.Status.Tests[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, i := range schedule(suite) {
+		result := &suite.Status.Tests[i]
+		if err := action.startTest(ctx, suite, suite.Spec.Tests[i], result); err != nil {
+			result.Phase = v1alpha1.TestPhaseError
+			result.Errors = v1alpha1.TestErrors{{Test: result.Test, Message: err.Error()}}
+			continue
+		}
+		action.L.Infof("Started test %s of suite %s", result.Test, suite.Name)
+	}
+
+	aggregate(suite)
+	return suite, nil
+}
+
+// updateResult copies the state of a started test into the suite status
+func (action *runAction) updateResult(ctx context.Context, suite *v1alpha1.TestSuite, result *v1alpha1.TestSuiteResult) error {
+	if result.Phase == v1alpha1.TestPhaseNone || IsFinished(result.Phase) {
+		return nil
+	}
+
+	test := v1alpha1.Test{}
+	key := client.ObjectKey{Namespace: suite.Namespace, Name: result.Test}
+	if err := action.client.Get(ctx, key, &test); err != nil && k8serrors.IsNotFound(err) {
+		result.Phase = v1alpha1.TestPhaseError
+		result.Errors = v1alpha1.TestErrors{{Test: result.Test, Message: "test has been deleted before it finished"}}
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	result.Phase = test.Status.Phase
+	if result.Phase == v1alpha1.TestPhaseNone || result.Phase == v1alpha1.TestPhaseDeleting {
+		result.Phase = v1alpha1.TestPhasePending
+	}
+	result.Results = *test.Status.Results.DeepCopy()
+	result.Errors = test.Status.Errors.DeepCopy()
+	return nil
+}
+
+// startTest creates the test of the given suite entry. The suite owns the test so it gets removed with the suite.
+func (action *runAction) startTest(ctx context.Context, suite *v1alpha1.TestSuite, entry v1alpha1.TestSuiteEntry, result *v1alpha1.TestSuiteResult) error {
+	spec := entry.Spec
+	if spec == nil {
+		ref := v1alpha1.Test{}
+		key := client.ObjectKey{Namespace: suite.Namespace, Name: RefFor(entry)}
+		if err := action.client.Get(ctx, key, &ref); err != nil {
+			return err
+		}
+		spec = &ref.Spec
+	}
+
+	controller := true
+	blockOwnerDeletion := true
+	test := v1alpha1.Test{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha1.TestKind,
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: suite.Namespace,
+			Name:      result.Test,
+			Labels: map[string]string{
+				v1alpha1.TestSuiteLabel: suite.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         v1alpha1.SchemeGroupVersion.String(),
+					Kind:               v1alpha1.TestSuiteKind,
+					Name:               suite.Name,
+					UID:                suite.UID,
+					Controller:         &controller,
+					BlockOwnerDeletion: &blockOwnerDeletion,
+				},
+			},
+		},
+		Spec: *spec.DeepCopy(),
+	}
+
+	err := action.client.Create(ctx, &test)
+	if err != nil && k8serrors.IsAlreadyExists(err) {
+		// the test may have been created by a previous reconcile whose status update got lost
+		existing := v1alpha1.Test{}
+		if err := action.client.Get(ctx, client.ObjectKey{Namespace: suite.Namespace, Name: result.Test}, &existing); err != nil {
+			return err
+		}
+		if owner := metav1.GetControllerOf(&existing); owner == nil || owner.UID != suite.UID {
+			return fmt.Errorf("test %s already exists and does not belong to suite %s", result.Test, suite.Name)
+		}
+	} else if err != nil {
+		return err
+	}
+
+	result.Phase = v1alpha1.TestPhasePending
+	return nil
+}
@@ -0,0 +1,188 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testsuite
+
+import (
+	"context"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/citrusframework/yaks/pkg/client"
+	"github.com/citrusframework/yaks/pkg/util/log"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Add creates a new TestSuite Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	c, err := client.FromManager(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(c, mgr.GetConfig()))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(c client.Client, cfg *rest.Config) reconcile.Reconciler {
+	return &ReconcileTestSuite{
+		client: c,
+		config: cfg,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("testsuite-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource TestSuite
+	err = c.Watch(&source.Kind{Type: &v1alpha1.TestSuite{}}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSuite := e.ObjectOld.(*v1alpha1.TestSuite)
+			newSuite := e.ObjectNew.(*v1alpha1.TestSuite)
+			// Ignore updates to the suite status in which case metadata.Generation does not change,
+			// or except when the suite phase changes as it's used to transition from one phase
+			// to another
+			return oldSuite.Generation != newSuite.Generation ||
+				oldSuite.Status.Phase != newSuite.Status.Phase
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			// Evaluates to false if the object has been confirmed deleted
+			return !e.DeleteStateUnknown
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the tests run by a suite
+	err = c.Watch(&source.Kind{Type: &v1alpha1.Test{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &v1alpha1.TestSuite{},
+	}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldTest := e.ObjectOld.(*v1alpha1.Test)
+			newTest := e.ObjectNew.(*v1alpha1.Test)
+			return oldTest.Status.Phase != newTest.Status.Phase
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileTestSuite{}
+
+// ReconcileTestSuite reconciles a TestSuite object
+type ReconcileTestSuite struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client client.Client
+	config *rest.Config
+}
+
+// Reconcile reads that state of the cluster for a TestSuite object and starts the tests of the suite according to
+// its execution policy
+func (r *ReconcileTestSuite) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	rlog := Log.WithValues("request-namespace", request.Namespace, "request-name", request.Name)
+	rlog.Info("Reconciling TestSuite")
+
+	ctx := context.TODO()
+
+	var instance v1alpha1.TestSuite
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Owned tests are automatically garbage collected
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if instance.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	target := instance.DeepCopy()
+	targetLog := rlog.ForTestSuite(target)
+
+	actions := []Action{
+		NewInitializeAction(),
+		NewRunAction(),
+	}
+
+	for _, a := range actions {
+		a.InjectClient(r.client)
+		a.InjectConfig(r.config)
+		a.InjectLogger(targetLog)
+
+		if a.CanHandle(target) {
+			targetLog.Infof("Invoking action %s", a.Name())
+
+			newTarget, err := a.Handle(ctx, target)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+
+			if newTarget != nil {
+				if r, err := r.update(ctx, targetLog, newTarget); err != nil {
+					return r, err
+				}
+
+				if newTarget.Status.Phase != instance.Status.Phase {
+					targetLog.Info(
+						"state transition",
+						"phase-from", instance.Status.Phase,
+						"phase-to", newTarget.Status.Phase,
+					)
+				}
+			}
+
+			// handle one action at time so the resource
+			// is always at its latest state
+			break
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileTestSuite) update(ctx context.Context, log log.Logger, target *v1alpha1.TestSuite) (reconcile.Result, error) {
+	err := r.client.Status().Update(ctx, target)
+	if err != nil {
+		if k8serrors.IsConflict(err) {
+			log.Error(err, "conflict")
+
+			return reconcile.Result{
+				Requeue: true,
+			}, nil
+		}
+	}
+
+	return reconcile.Result{}, err
+}
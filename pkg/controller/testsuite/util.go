@@ -0,0 +1,177 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testsuite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// TestNameFor returns the name of the test the suite runs for the given entry
+func TestNameFor(suite *v1alpha1.TestSuite, entry v1alpha1.TestSuiteEntry) string {
+	return fmt.Sprintf("%s-%s", suite.Name, entry.Name)
+}
+
+// PolicyFor returns the execution policy of the suite, tests run sequentially by default
+func PolicyFor(suite *v1alpha1.TestSuite) v1alpha1.TestSuitePolicy {
+	if suite.Spec.Policy == "" {
+		return v1alpha1.TestSuitePolicySequential
+	}
+	return suite.Spec.Policy
+}
+
+// RefFor returns the name of the test referenced by the given entry
+func RefFor(entry v1alpha1.TestSuiteEntry) string {
+	if entry.Ref == "" {
+		return entry.Name
+	}
+	return entry.Ref
+}
+
+// Validate checks the spec of the test suite. Embedded tests are validated once the suite creates them.
+func Validate(suite *v1alpha1.TestSuite) error {
+	policy := PolicyFor(suite)
+	supported := false
+	for _, p := range v1alpha1.TestSuitePolicies {
+		if p == policy {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return fmt.Errorf("unsupported execution policy '%s', expected one of %v", policy, v1alpha1.TestSuitePolicies)
+	}
+
+	if len(suite.Spec.Tests) == 0 {
+		return fmt.Errorf("test suite %s does not define any tests", suite.Name)
+	}
+
+	names := make(map[string]bool)
+	for _, entry := range suite.Spec.Tests {
+		if entry.Name == "" {
+			return fmt.Errorf("missing name of test in suite %s", suite.Name)
+		}
+		if names[entry.Name] {
+			return fmt.Errorf("duplicate test '%s' in suite %s", entry.Name, suite.Name)
+		}
+		names[entry.Name] = true
+
+		if entry.Spec != nil && entry.Ref != "" {
+			return fmt.Errorf("test '%s' must either reference an existing test or define a spec", entry.Name)
+		}
+		if errs := validation.IsDNS1123Subdomain(TestNameFor(suite, entry)); len(errs) > 0 {
+			return fmt.Errorf("invalid test name '%s': %s", TestNameFor(suite, entry), strings.Join(errs, ", "))
+		}
+	}
+
+	return nil
+}
+
+// IsFinished tells whether a test has reached a final phase
+func IsFinished(phase v1alpha1.TestPhase) bool {
+	return phase == v1alpha1.TestPhasePassed ||
+		phase == v1alpha1.TestPhaseFailed ||
+		phase == v1alpha1.TestPhaseError ||
+		phase == v1alpha1.TestPhaseSkipped
+}
+
+func isSuccessful(phase v1alpha1.TestPhase) bool {
+	return phase == v1alpha1.TestPhasePassed
+}
+
+// schedule returns the indexes of the suite tests to start next according to the execution policy. With the fail fast
+// policy all tests that have not been started yet get skipped after the first failure.
+func schedule(suite *v1alpha1.TestSuite) []int {
+	policy := PolicyFor(suite)
+	next := make([]int, 0)
+
+	for i := range suite.Status.Tests {
+		result := &suite.Status.Tests[i]
+		if result.Phase != v1alpha1.TestPhaseNone {
+			if IsFinished(result.Phase) {
+				if policy == v1alpha1.TestSuitePolicyFailFast && !isSuccessful(result.Phase) {
+					skipRemaining(suite, i+1)
+					return next
+				}
+				continue
+			}
+			if policy != v1alpha1.TestSuitePolicyParallel {
+				// wait for the running test to finish
+				return next
+			}
+			continue
+		}
+
+		next = append(next, i)
+		if policy != v1alpha1.TestSuitePolicyParallel {
+			return next
+		}
+	}
+
+	return next
+}
+
+func skipRemaining(suite *v1alpha1.TestSuite, from int) {
+	for i := from; i < len(suite.Status.Tests); i++ {
+		if suite.Status.Tests[i].Phase == v1alpha1.TestPhaseNone {
+			suite.Status.Tests[i].Phase = v1alpha1.TestPhaseSkipped
+		}
+	}
+}
+
+// aggregate sums up the results of all tests in the suite and computes the phase of the suite
+func aggregate(suite *v1alpha1.TestSuite) {
+	summary := v1alpha1.TestSummary{}
+	errors := make(v1alpha1.TestErrors, 0)
+	finished := true
+	successful := true
+
+	for _, result := range suite.Status.Tests {
+		summary.Total += result.Results.Summary.Total
+		summary.Passed += result.Results.Summary.Passed
+		summary.Failed += result.Results.Summary.Failed
+		summary.Skipped += result.Results.Summary.Skipped
+		summary.Pending += result.Results.Summary.Pending
+		summary.Undefined += result.Results.Summary.Undefined
+		summary.Flaky += result.Results.Summary.Flaky
+		errors = append(errors, result.Errors...)
+
+		if !IsFinished(result.Phase) {
+			finished = false
+		} else if !isSuccessful(result.Phase) {
+			successful = false
+		}
+	}
+
+	suite.Status.Summary = summary
+	suite.Status.Errors = nil
+	if len(errors) > 0 {
+		suite.Status.Errors = errors
+	}
+
+	if !finished {
+		suite.Status.Phase = v1alpha1.TestPhaseRunning
+	} else if successful {
+		suite.Status.Phase = v1alpha1.TestPhasePassed
+	} else {
+		suite.Status.Phase = v1alpha1.TestPhaseFailed
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testsuite
+
+import (
+	"testing"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newSuite(policy v1alpha1.TestSuitePolicy, phases ...v1alpha1.TestPhase) *v1alpha1.TestSuite {
+	suite := v1alpha1.TestSuite{
+		ObjectMeta: metav1.ObjectMeta{Name: "suite"},
+		Spec:       v1alpha1.TestSuiteSpec{Policy: policy},
+	}
+	for i, phase := range phases {
+		name := string(rune('a' + i))
+		suite.Spec.Tests = append(suite.Spec.Tests, v1alpha1.TestSuiteEntry{Name: name})
+		suite.Status.Tests = append(suite.Status.Tests, v1alpha1.TestSuiteResult{Name: name, Test: "suite-" + name, Phase: phase})
+	}
+	return &suite
+}
+
+func TestScheduleSequential(t *testing.T) {
+	suite := newSuite("", v1alpha1.TestPhaseNone, v1alpha1.TestPhaseNone)
+	assert.Equal(t, []int{0}, schedule(suite))
+
+	suite = newSuite("", v1alpha1.TestPhaseRunning, v1alpha1.TestPhaseNone)
+	assert.Equal(t, []int{}, schedule(suite))
+
+	suite = newSuite(v1alpha1.TestSuitePolicySequential, v1alpha1.TestPhaseFailed, v1alpha1.TestPhaseNone)
+	assert.Equal(t, []int{1}, schedule(suite))
+}
+
+func TestScheduleParallel(t *testing.T) {
+	suite := newSuite(v1alpha1.TestSuitePolicyParallel, v1alpha1.TestPhaseRunning, v1alpha1.TestPhaseNone, v1alpha1.TestPhaseNone)
+	assert.Equal(t, []int{1, 2}, schedule(suite))
+}
+
+func TestScheduleFailFast(t *testing.T) {
+	suite := newSuite(v1alpha1.TestSuitePolicyFailFast, v1alpha1.TestPhasePassed, v1alpha1.TestPhaseNone)
+	assert.Equal(t, []int{1}, schedule(suite))
+
+	suite = newSuite(v1alpha1.TestSuitePolicyFailFast, v1alpha1.TestPhaseError, v1alpha1.TestPhaseNone, v1alpha1.TestPhaseNone)
+	assert.Equal(t, []int{}, schedule(suite))
+	assert.Equal(t, v1alpha1.TestPhaseSkipped, suite.Status.Tests[1].Phase)
+	assert.Equal(t, v1alpha1.TestPhaseSkipped, suite.Status.Tests[2].Phase)
+
+	aggregate(suite)
+	assert.Equal(t, v1alpha1.TestPhaseFailed, suite.Status.Phase)
+}
+
+func TestAggregate(t *testing.T) {
+	suite := newSuite("", v1alpha1.TestPhasePassed, v1alpha1.TestPhaseRunning)
+	suite.Status.Tests[0].Results.Summary = v1alpha1.TestSummary{Total: 2, Passed: 2}
+	aggregate(suite)
+	assert.Equal(t, v1alpha1.TestPhaseRunning, suite.Status.Phase)
+	assert.Equal(t, 2, suite.Status.Summary.Total)
+
+	suite.Status.Tests[1].Phase = v1alpha1.TestPhaseFailed
+	suite.Status.Tests[1].Results.Summary = v1alpha1.TestSummary{Total: 3, Passed: 1, Failed: 2}
+	suite.Status.Tests[1].Errors = v1alpha1.TestErrors{{Test: "b.feature", Message: "expected"}}
+	aggregate(suite)
+	assert.Equal(t, v1alpha1.TestPhaseFailed, suite.Status.Phase)
+	assert.Equal(t, v1alpha1.TestSummary{Total: 5, Passed: 3, Failed: 2}, suite.Status.Summary)
+	assert.Equal(t, v1alpha1.TestErrors{{Test: "b.feature", Message: "expected"}}, suite.Status.Errors)
+
+	suite = newSuite(v1alpha1.TestSuitePolicyParallel, v1alpha1.TestPhasePassed, v1alpha1.TestPhasePassed)
+	aggregate(suite)
+	assert.Equal(t, v1alpha1.TestPhasePassed, suite.Status.Phase)
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Validate(newSuite("", v1alpha1.TestPhaseNone)))
+	assert.NotNil(t, Validate(newSuite("")))
+	assert.NotNil(t, Validate(newSuite("Random", v1alpha1.TestPhaseNone)))
+
+	suite := newSuite("", v1alpha1.TestPhaseNone, v1alpha1.TestPhaseNone)
+	suite.Spec.Tests[1].Name = "a"
+	assert.NotNil(t, Validate(suite))
+
+	suite = newSuite("", v1alpha1.TestPhaseNone)
+	suite.Spec.Tests[0].Name = "Invalid_Name"
+	assert.NotNil(t, Validate(suite))
+
+	suite = newSuite("", v1alpha1.TestPhaseNone)
+	suite.Spec.Tests[0].Ref = "hello"
+	suite.Spec.Tests[0].Spec = &v1alpha1.TestSpec{}
+	assert.NotNil(t, Validate(suite))
+}
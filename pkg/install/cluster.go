@@ -29,10 +29,12 @@ import (
 	"github.com/citrusframework/yaks/pkg/util/kubernetes/customclient"
 
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/rest"
 
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -54,6 +56,16 @@ func SetupClusterwideResourcesOrCollect(ctx context.Context, clientProvider clie
 		return err
 	}
 
+	// Install CRD for TestSuite
+	if err := installCRD(ctx, c, "TestSuite", "crds/yaks_v1alpha1_testsuite_crd.yaml", collection); err != nil {
+		return err
+	}
+
+	// Install CRD for TestSchedule
+	if err := installCRD(ctx, c, "TestSchedule", "crds/yaks_v1alpha1_testschedule_crd.yaml", collection); err != nil {
+		return err
+	}
+
 	// Installing ClusterRole
 	clusterRoleInstalled, err := IsClusterRoleInstalled(ctx, c)
 	if err != nil {
@@ -99,7 +111,12 @@ func WaitForAllCRDInstallation(ctx context.Context, clientProvider client.Provid
 
 // AreAllCRDInstalled check if all the required CRDs are installed
 func AreAllCRDInstalled(ctx context.Context, c client.Client) (bool, error) {
-	return IsCRDInstalled(ctx, c, "Test")
+	for _, kind := range []string{"Test", "TestSuite"} {
+		if ok, err := IsCRDInstalled(ctx, c, kind); err != nil || !ok {
+			return ok, err
+		}
+	}
+	return IsCRDInstalled(ctx, c, "TestSchedule")
 }
 
 // IsCRDInstalled check if the given CRD kind is installed
@@ -129,23 +146,24 @@ func installCRD(ctx context.Context, c client.Client, kind string, resourceName
 		return nil
 	}
 
+	restClient, err := customclient.GetClientFor(c, "apiextensions.k8s.io", "v1")
+	if err != nil {
+		return err
+	}
+
 	// Installing Integration CRD
 	installed, err := IsCRDInstalled(ctx, c, kind)
 	if err != nil {
 		return err
 	}
 	if installed {
-		return nil
+		return upgradeCRD(restClient, crd)
 	}
 
 	crdJSON, err := yaml.ToJSON(crd)
 	if err != nil {
 		return err
 	}
-	restClient, err := customclient.GetClientFor(c, "apiextensions.k8s.io", "v1beta1")
-	if err != nil {
-		return err
-	}
 	// Post using dynamic client
 	result := restClient.
 		Post().
@@ -160,6 +178,101 @@ func installCRD(ctx context.Context, c client.Client, kind string, resourceName
 	return nil
 }
 
+// upgradeCRD replaces an installed CRD with the given CRD so that schema changes of new versions get applied. A
+// conversion webhook configured by a previous installation is kept together with the API versions it serves.
+func upgradeCRD(restClient rest.Interface, crd []byte) error {
+	desired, err := kubernetes.LoadRawResourceFromYaml(string(crd))
+	if err != nil {
+		return err
+	}
+	target := desired.(*unstructured.Unstructured)
+
+	current, err := getCRD(restClient, target.GetName())
+	if err != nil {
+		return err
+	}
+
+	if conversionNamespaceOf(current) != "" {
+		conversion, _, err := unstructured.NestedMap(current.Object, "spec", "conversion")
+		if err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedMap(target.Object, conversion, "spec", "conversion"); err != nil {
+			return err
+		}
+
+		served := servedVersions(current)
+		if err := setServedVersions(target, func(name string) bool { return served[name] }); err != nil {
+			return err
+		}
+	}
+
+	target.SetResourceVersion(current.GetResourceVersion())
+	return updateCRD(restClient, target)
+}
+
+func getCRD(restClient rest.Interface, name string) (*unstructured.Unstructured, error) {
+	data, err := restClient.
+		Get().
+		Resource("customresourcedefinitions").
+		Name(name).
+		Do().
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	crd := unstructured.Unstructured{}
+	if err := crd.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return &crd, nil
+}
+
+func updateCRD(restClient rest.Interface, crd *unstructured.Unstructured) error {
+	data, err := crd.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return restClient.
+		Put().
+		Resource("customresourcedefinitions").
+		Name(crd.GetName()).
+		Body(data).
+		Do().
+		Error()
+}
+
+// servedVersions returns the API versions of the given CRD that are served
+func servedVersions(crd *unstructured.Unstructured) map[string]bool {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	served := make(map[string]bool, len(versions))
+	for _, version := range versions {
+		if v, ok := version.(map[string]interface{}); ok {
+			if name, ok := v["name"].(string); ok {
+				served[name], _ = v["served"].(bool)
+			}
+		}
+	}
+	return served
+}
+
+// setServedVersions sets which API versions of the given CRD are served. The storage version is always served.
+func setServedVersions(crd *unstructured.Unstructured, served func(name string) bool) error {
+	versions, _, err := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		if v, ok := version.(map[string]interface{}); ok {
+			name, _ := v["name"].(string)
+			storage, _ := v["storage"].(bool)
+			v["served"] = storage || served(name)
+		}
+	}
+	return unstructured.SetNestedSlice(crd.Object, versions, "spec", "versions")
+}
+
 // IsClusterRoleInstalled check if cluster role camel-k:edit is installed
 func IsClusterRoleInstalled(ctx context.Context, c client.Client) (bool, error) {
 	clusterRole := rbacv1.ClusterRole{
@@ -19,13 +19,56 @@ package install
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/citrusframework/yaks/pkg/client"
+	"github.com/citrusframework/yaks/pkg/config"
 	"github.com/citrusframework/yaks/pkg/util/kubernetes"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OperatorComponentLabel identifies the operator deployment
+	OperatorComponentLabel = "org.citrusframework.yaks/component"
+	// OperatorScopeLabel marks the operator deployment as global (watching all namespaces)
+	OperatorScopeLabel = "org.citrusframework.yaks/operator-scope"
+
+	OperatorScopeGlobal = "global"
+
+	// NamespacePoolSizeEnv holds the size of the temporary namespace pool on the operator deployment
+	NamespacePoolSizeEnv = "YAKS_NAMESPACE_POOL_SIZE"
 )
 
 // OperatorConfiguration --
 type OperatorConfiguration struct {
 	Namespace string
+	Global    bool
+	Webhook   bool
+	// Conversion lets the operator serve the conversion of tests between the API versions of the CRD
+	Conversion         bool
+	ConversionTakeover bool
+	Maven              MavenConfiguration
+	// NamespacePoolSize is the number of pre-warmed temporary namespaces test runs in the namespace of the operator use
+	NamespacePoolSize int
+}
+
+// MavenConfiguration holds the operator wide Maven settings applied to all tests that do not define their own
+type MavenConfiguration struct {
+	Settings     string
+	Mirror       string
+	MirrorSecret string
+
+	CacheSize         string
+	CacheStorageClass string
+	CacheAccessMode   string
 }
 
 // Operator installs the operator resources in the given namespace
@@ -35,10 +78,239 @@ func Operator(ctx context.Context, c client.Client, cfg OperatorConfiguration) e
 
 // OperatorOrCollect installs the operator resources or adds them to the collector if present
 func OperatorOrCollect(ctx context.Context, c client.Client, cfg OperatorConfiguration, collection *kubernetes.Collection) error {
-	return ResourcesOrCollect(ctx, c, cfg.Namespace, collection, IdentityResourceCustomizer,
+	if cfg.Webhook && collection == nil {
+		if err := Webhook(ctx, c, cfg.Namespace, cfg.Global); err != nil {
+			return err
+		}
+	}
+
+	customizer := func(object runtime.Object) runtime.Object {
+		object = mavenCustomizer(cfg.Maven)(object)
+		object = namespacePoolCustomizer(cfg.NamespacePoolSize)(object)
+		if cfg.Webhook || cfg.Conversion {
+			object = webhookCustomizer(object)
+		}
+		return object
+	}
+
+	if cfg.Global {
+		return ResourcesOrCollect(ctx, c, cfg.Namespace, collection, func(object runtime.Object) runtime.Object {
+			return customizer(globalOperatorCustomizer(cfg.Namespace)(object))
+		},
+			"service_account.yaml",
+			"operator_cluster_role.yaml",
+			"operator_cluster_role_binding.yaml",
+			"operator.yaml",
+		)
+	}
+
+	return ResourcesOrCollect(ctx, c, cfg.Namespace, collection, customizer,
 		"service_account.yaml",
 		"role.yaml",
 		"role_binding.yaml",
 		"operator.yaml",
 	)
 }
+
+// globalOperatorCustomizer binds the cluster role to the operator service account and lets the operator watch all namespaces
+func globalOperatorCustomizer(namespace string) ResourceCustomizer {
+	return func(object runtime.Object) runtime.Object {
+		switch o := object.(type) {
+		case *rbacv1.ClusterRoleBinding:
+			for i := range o.Subjects {
+				o.Subjects[i].Namespace = namespace
+			}
+		case *appsv1.Deployment:
+			if o.Labels == nil {
+				o.Labels = make(map[string]string)
+			}
+			o.Labels[OperatorScopeLabel] = OperatorScopeGlobal
+
+			for i := range o.Spec.Template.Spec.Containers {
+				container := &o.Spec.Template.Spec.Containers[i]
+				for j := range container.Env {
+					if container.Env[j].Name == "WATCH_NAMESPACE" {
+						// an empty watch namespace makes the operator watch all namespaces
+						container.Env[j] = corev1.EnvVar{Name: "WATCH_NAMESPACE", Value: ""}
+					}
+				}
+			}
+		}
+		return object
+	}
+}
+
+// mavenCustomizer passes the operator wide Maven settings to the operator deployment
+func mavenCustomizer(maven MavenConfiguration) ResourceCustomizer {
+	return func(object runtime.Object) runtime.Object {
+		if deployment, ok := object.(*appsv1.Deployment); ok {
+			settings := []corev1.EnvVar{
+				{Name: "YAKS_MAVEN_SETTINGS", Value: maven.Settings},
+				{Name: "YAKS_MAVEN_MIRROR", Value: maven.Mirror},
+				{Name: "YAKS_MAVEN_MIRROR_SECRET", Value: maven.MirrorSecret},
+				{Name: "YAKS_MAVEN_CACHE_SIZE", Value: maven.CacheSize},
+				{Name: "YAKS_MAVEN_CACHE_STORAGE_CLASS", Value: maven.CacheStorageClass},
+				{Name: "YAKS_MAVEN_CACHE_ACCESS_MODE", Value: maven.CacheAccessMode},
+			}
+
+			for i := range deployment.Spec.Template.Spec.Containers {
+				container := &deployment.Spec.Template.Spec.Containers[i]
+				for _, setting := range settings {
+					if setting.Value != "" {
+						container.Env = append(container.Env, setting)
+					}
+				}
+			}
+		}
+		return object
+	}
+}
+
+// namespacePoolCustomizer passes the size of the temporary namespace pool to the operator deployment
+func namespacePoolCustomizer(size int) ResourceCustomizer {
+	return func(object runtime.Object) runtime.Object {
+		if deployment, ok := object.(*appsv1.Deployment); ok && size > 0 {
+			for i := range deployment.Spec.Template.Spec.Containers {
+				container := &deployment.Spec.Template.Spec.Containers[i]
+				container.Env = append(container.Env, corev1.EnvVar{Name: NamespacePoolSizeEnv, Value: strconv.Itoa(size)})
+			}
+		}
+		return object
+	}
+}
+
+// OperatorMavenConfiguration returns the operator wide Maven settings of the operator installed in the given namespace.
+// The settings are empty when there is no operator in the namespace.
+func OperatorMavenConfiguration(ctx context.Context, c client.Client, namespace string) (MavenConfiguration, error) {
+	maven := MavenConfiguration{}
+	deployments := appsv1.DeploymentList{}
+	err := c.List(ctx, &deployments, k8sclient.InNamespace(namespace), k8sclient.MatchingLabels{
+		OperatorComponentLabel: "operator",
+	})
+	if err != nil {
+		return maven, err
+	}
+
+	for _, deployment := range deployments.Items {
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			for _, env := range container.Env {
+				switch env.Name {
+				case "YAKS_MAVEN_SETTINGS":
+					maven.Settings = env.Value
+				case "YAKS_MAVEN_MIRROR":
+					maven.Mirror = env.Value
+				case "YAKS_MAVEN_MIRROR_SECRET":
+					maven.MirrorSecret = env.Value
+				case "YAKS_MAVEN_CACHE_SIZE":
+					maven.CacheSize = env.Value
+				case "YAKS_MAVEN_CACHE_STORAGE_CLASS":
+					maven.CacheStorageClass = env.Value
+				case "YAKS_MAVEN_CACHE_ACCESS_MODE":
+					maven.CacheAccessMode = env.Value
+				}
+			}
+		}
+	}
+	return maven, nil
+}
+
+// OperatorTestTimeout returns the default test timeout of the operator handling the given namespace. The built-in
+// default applies when the operator does not customize the timeout or cannot be found.
+func OperatorTestTimeout(ctx context.Context, c client.Client, namespace string) (string, error) {
+	timeout, err := operatorEnv(ctx, c, namespace, "YAKS_TEST_TIMEOUT")
+	if err != nil || timeout != "" {
+		return timeout, err
+	}
+	return config.DefaultTestTimeout, nil
+}
+
+// OperatorNamespacePoolSize returns the size of the temporary namespace pool configured on the operator handling the
+// given namespace. The size is zero when the operator does not use a pool or cannot be found.
+func OperatorNamespacePoolSize(ctx context.Context, c client.Client, namespace string) (int, error) {
+	size, err := operatorEnv(ctx, c, namespace, NamespacePoolSizeEnv)
+	if err != nil || size == "" {
+		return 0, err
+	}
+
+	value, err := strconv.Atoi(size)
+	if err != nil {
+		return 0, errors.Wrap(err, fmt.Sprintf("invalid namespace pool size of the operator handling namespace %s", namespace))
+	}
+	return value, nil
+}
+
+// operatorEnv returns the value of the environment variable set on the operator handling the given namespace. This is
+// the operator installed in the namespace or else the global operator.
+func operatorEnv(ctx context.Context, c client.Client, namespace string, name string) (string, error) {
+	deployments := appsv1.DeploymentList{}
+	err := c.List(ctx, &deployments, k8sclient.InNamespace(namespace), k8sclient.MatchingLabels{
+		OperatorComponentLabel: "operator",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(deployments.Items) == 0 {
+		err = c.List(ctx, &deployments, k8sclient.MatchingLabels{
+			OperatorComponentLabel: "operator",
+			OperatorScopeLabel:     OperatorScopeGlobal,
+		})
+		if err != nil && !k8serrors.IsForbidden(err) && !k8serrors.IsNotFound(err) {
+			return "", err
+		}
+	}
+
+	for _, deployment := range deployments.Items {
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			for _, env := range container.Env {
+				if env.Name == name && env.Value != "" {
+					return env.Value, nil
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+// IsGlobalOperatorInstalled checks if there is an operator watching all namespaces in the cluster. The function
+// returns false when the current user is not allowed to list deployments cluster-wide.
+func IsGlobalOperatorInstalled(ctx context.Context, c client.Client) (bool, error) {
+	deployments := appsv1.DeploymentList{}
+	err := c.List(ctx, &deployments, k8sclient.MatchingLabels{
+		OperatorComponentLabel: "operator",
+		OperatorScopeLabel:     OperatorScopeGlobal,
+	})
+	if err != nil && (k8serrors.IsForbidden(err) || k8serrors.IsNotFound(err)) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return len(deployments.Items) > 0, nil
+}
+
+// WaitForOperator waits until the operator deployment in the given namespace has an available replica. It returns
+// right away when there is no operator in the namespace, e.g. because a global operator handles the namespace.
+func WaitForOperator(ctx context.Context, c client.Client, namespace string, timeout time.Duration) error {
+	deployments := appsv1.DeploymentList{}
+	err := c.List(ctx, &deployments, k8sclient.InNamespace(namespace), k8sclient.MatchingLabels{
+		OperatorComponentLabel: "operator",
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		err := kubernetes.WaitCondition(ctx, c, deployment, func(obj interface{}) (bool, error) {
+			if val, ok := obj.(*appsv1.Deployment); ok {
+				return val.Status.AvailableReplicas > 0, nil
+			}
+			return false, nil
+		}, timeout)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("operator in namespace %s is not ready", namespace))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/citrusframework/yaks/pkg/client"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientscheme "k8s.io/client-go/kubernetes/scheme"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeClient struct {
+	k8sclient.Client
+	kubernetes.Interface
+}
+
+func (c *fakeClient) GetScheme() *runtime.Scheme {
+	return clientscheme.Scheme
+}
+
+func newFakeClient(objects ...runtime.Object) client.Client {
+	return &fakeClient{
+		Client:    fake.NewFakeClientWithScheme(clientscheme.Scheme, objects...),
+		Interface: kubefake.NewSimpleClientset(),
+	}
+}
+
+func newOperatorDeployment(namespace string, labels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "yaks-operator",
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "yaks-operator",
+							Env: []corev1.EnvVar{
+								{
+									Name: "WATCH_NAMESPACE",
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+									},
+								},
+								{Name: "OPERATOR_NAME", Value: "yaks"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGlobalOperatorCustomizer(t *testing.T) {
+	customizer := globalOperatorCustomizer("yaks")
+
+	binding := customizer(&rbacv1.ClusterRoleBinding{
+		Subjects: []rbacv1.Subject{{Kind: "ServiceAccount", Name: "yaks-operator"}},
+	}).(*rbacv1.ClusterRoleBinding)
+	assert.Equal(t, "yaks", binding.Subjects[0].Namespace)
+
+	deployment := customizer(newOperatorDeployment("yaks", nil)).(*appsv1.Deployment)
+	assert.Equal(t, OperatorScopeGlobal, deployment.Labels[OperatorScopeLabel])
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "WATCH_NAMESPACE", Value: ""},
+		{Name: "OPERATOR_NAME", Value: "yaks"},
+	}, deployment.Spec.Template.Spec.Containers[0].Env)
+}
+
+func TestIsGlobalOperatorInstalled(t *testing.T) {
+	c := newFakeClient(newOperatorDeployment("team", map[string]string{OperatorComponentLabel: "operator"}))
+	global, err := IsGlobalOperatorInstalled(context.TODO(), c)
+	assert.Nil(t, err)
+	assert.False(t, global)
+
+	c = newFakeClient(newOperatorDeployment("yaks", map[string]string{
+		OperatorComponentLabel: "operator",
+		OperatorScopeLabel:     OperatorScopeGlobal,
+	}))
+	global, err = IsGlobalOperatorInstalled(context.TODO(), c)
+	assert.Nil(t, err)
+	assert.True(t, global)
+}
@@ -0,0 +1,427 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package install
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/citrusframework/yaks/pkg/client"
+	"github.com/citrusframework/yaks/pkg/util/certificate"
+	"github.com/citrusframework/yaks/pkg/util/kubernetes"
+	"github.com/citrusframework/yaks/pkg/util/kubernetes/customclient"
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/rest"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// WebhookServiceName is the name of the service exposing the admission webhooks of the operator
+	WebhookServiceName = "yaks-webhook"
+	// WebhookSecretName is the name of the secret holding the serving certificate of the admission webhooks
+	WebhookSecretName = "yaks-webhook-cert"
+	// WebhookNamespaceLabel marks the namespace a namespaced operator validates tests for as well as the cluster wide
+	// webhook configurations installed for the operator in that namespace
+	WebhookNamespaceLabel = "org.citrusframework.yaks/webhook"
+
+	webhookPort     = 9443
+	webhookCertDir  = "/etc/yaks/webhook"
+	webhookValidity = 10 * 365 * 24 * time.Hour
+	webhookMutate   = "/mutate-test"
+	webhookValidate = "/validate-test"
+	webhookConvert  = "/convert"
+	testCRDName     = "tests.org.citrusframework.yaks"
+)
+
+// Webhook installs the serving certificate, the service and the webhook configurations of the operator admission webhooks.
+// A namespaced operator only handles tests in its own namespace while a global operator handles tests in all namespaces.
+// The webhook configurations are cluster wide, so they are labeled with the operator namespace in order to remove them
+// together with the namespace.
+func Webhook(ctx context.Context, c client.Client, namespace string, global bool) error {
+	bundle, err := webhookService(ctx, c, namespace)
+	if err != nil {
+		return err
+	}
+
+	var selector *metav1.LabelSelector
+	if !global {
+		if err := labelWebhookNamespace(ctx, c, namespace); err != nil {
+			return err
+		}
+		selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				WebhookNamespaceLabel: namespace,
+			},
+		}
+	}
+
+	name := WebhookServiceName + "-" + namespace
+	labels := map[string]string{
+		"app":                 "yaks",
+		WebhookNamespaceLabel: namespace,
+	}
+	failurePolicy := admissionv1beta1.Ignore
+	sideEffects := admissionv1beta1.SideEffectClassNone
+	// tests created with other API versions get converted to v1alpha1 before they are sent to the webhooks
+	matchPolicy := admissionv1beta1.Equivalent
+	rules := []admissionv1beta1.RuleWithOperations{
+		{
+			Operations: []admissionv1beta1.OperationType{admissionv1beta1.Create, admissionv1beta1.Update},
+			Rule: admissionv1beta1.Rule{
+				APIGroups:   []string{"org.citrusframework.yaks"},
+				APIVersions: []string{"v1alpha1"},
+				Resources:   []string{"tests"},
+			},
+		},
+	}
+	clientConfig := func(path string) admissionv1beta1.WebhookClientConfig {
+		return admissionv1beta1.WebhookClientConfig{
+			Service: &admissionv1beta1.ServiceReference{
+				Namespace: namespace,
+				Name:      WebhookServiceName,
+				Path:      &path,
+			},
+			CABundle: bundle.CA,
+		}
+	}
+
+	objects := []runtime.Object{
+		&admissionv1beta1.MutatingWebhookConfiguration{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "MutatingWebhookConfiguration",
+				APIVersion: admissionv1beta1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+			Webhooks: []admissionv1beta1.MutatingWebhook{
+				{
+					Name:              "default.tests.org.citrusframework.yaks",
+					ClientConfig:      clientConfig(webhookMutate),
+					Rules:             rules,
+					FailurePolicy:     &failurePolicy,
+					SideEffects:       &sideEffects,
+					MatchPolicy:       &matchPolicy,
+					NamespaceSelector: selector,
+				},
+			},
+		},
+		&admissionv1beta1.ValidatingWebhookConfiguration{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ValidatingWebhookConfiguration",
+				APIVersion: admissionv1beta1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+			Webhooks: []admissionv1beta1.ValidatingWebhook{
+				{
+					Name:              "validate.tests.org.citrusframework.yaks",
+					ClientConfig:      clientConfig(webhookValidate),
+					Rules:             rules,
+					FailurePolicy:     &failurePolicy,
+					SideEffects:       &sideEffects,
+					MatchPolicy:       &matchPolicy,
+					NamespaceSelector: selector,
+				},
+			},
+		},
+	}
+
+	return kubernetes.ReplaceResources(ctx, c, objects)
+}
+
+// DeleteWebhooks removes the webhook configurations installed for the operator in the given namespace. Users that are
+// not allowed to manage webhook configurations leave them to the cluster admin.
+func DeleteWebhooks(ctx context.Context, c client.Client, namespace string) error {
+	objects := []runtime.Object{
+		&admissionv1beta1.MutatingWebhookConfiguration{},
+		&admissionv1beta1.ValidatingWebhookConfiguration{},
+	}
+
+	for _, obj := range objects {
+		err := c.DeleteAllOf(ctx, obj, k8sclient.MatchingLabels{WebhookNamespaceLabel: namespace})
+		if err != nil && !k8serrors.IsForbidden(err) && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// OrphanedWebhooks returns the operator namespaces that are gone or terminating while their webhook configurations
+// are still installed
+func OrphanedWebhooks(ctx context.Context, c client.Client) ([]string, error) {
+	mutating := admissionv1beta1.MutatingWebhookConfigurationList{}
+	if err := c.List(ctx, &mutating, k8sclient.MatchingLabels{"app": "yaks"}); err != nil && k8serrors.IsForbidden(err) {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	validating := admissionv1beta1.ValidatingWebhookConfigurationList{}
+	if err := c.List(ctx, &validating, k8sclient.MatchingLabels{"app": "yaks"}); err != nil && k8serrors.IsForbidden(err) {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	owners := make([]string, 0)
+	for _, item := range mutating.Items {
+		owners = append(owners, item.Labels[WebhookNamespaceLabel])
+	}
+	for _, item := range validating.Items {
+		owners = append(owners, item.Labels[WebhookNamespaceLabel])
+	}
+
+	orphaned := make([]string, 0)
+	known := make(map[string]bool)
+	for _, owner := range owners {
+		if owner == "" || known[owner] {
+			continue
+		}
+		known[owner] = true
+
+		ns := corev1.Namespace{}
+		err := c.Get(ctx, k8sclient.ObjectKey{Name: owner}, &ns)
+		if err != nil && k8serrors.IsNotFound(err) || err == nil && ns.Status.Phase == corev1.NamespaceTerminating {
+			orphaned = append(orphaned, owner)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return orphaned, nil
+}
+
+// webhookService installs the serving certificate and the service of the operator webhooks
+func webhookService(ctx context.Context, c client.Client, namespace string) (*certificate.Bundle, error) {
+	dnsNames := []string{
+		fmt.Sprintf("%s.%s.svc", WebhookServiceName, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", WebhookServiceName, namespace),
+	}
+
+	bundle, err := webhookCertificate(ctx, c, namespace, dnsNames)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []runtime.Object{
+		&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Secret",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      WebhookSecretName,
+				Labels:    map[string]string{"app": "yaks"},
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				"ca.crt":                bundle.CA,
+				corev1.TLSCertKey:       bundle.Cert,
+				corev1.TLSPrivateKeyKey: bundle.Key,
+			},
+		},
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      WebhookServiceName,
+				Labels:    map[string]string{"app": "yaks"},
+			},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"name": "yaks"},
+				Ports: []corev1.ServicePort{
+					{
+						Name:       "webhook",
+						Port:       443,
+						TargetPort: intstr.FromInt(webhookPort),
+						Protocol:   corev1.ProtocolTCP,
+					},
+				},
+			},
+		},
+	}
+
+	if err := kubernetes.ReplaceResources(ctx, c, objects); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// Conversion lets the operator in the given namespace convert tests between the API versions of the CRD and starts
+// serving all versions. The conversion is cluster wide, so it is tied to a single operator namespace. An operator in
+// another namespace only takes over when asked to or when the namespace of the current operator is gone. It returns
+// the namespace serving the conversion, which is empty when the user is not allowed to configure the CRD.
+func Conversion(ctx context.Context, c client.Client, namespace string, takeover bool) (string, error) {
+	restClient, err := customclient.GetClientFor(c, "apiextensions.k8s.io", "v1")
+	if err != nil {
+		return "", err
+	}
+	crd, err := getCRD(restClient, testCRDName)
+	if err != nil && k8serrors.IsForbidden(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if owner := conversionNamespaceOf(crd); owner != "" && owner != namespace && !takeover {
+		err := c.Get(ctx, k8sclient.ObjectKey{Name: owner}, &corev1.Namespace{})
+		if err == nil {
+			return owner, nil
+		} else if !k8serrors.IsNotFound(err) {
+			return "", err
+		}
+	}
+
+	bundle, err := webhookService(ctx, c, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	if err := enableConversion(restClient, crd, namespace, bundle.CA); err != nil && k8serrors.IsForbidden(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return namespace, nil
+}
+
+// conversionNamespaceOf returns the namespace of the conversion webhook service configured in the given CRD
+func conversionNamespaceOf(crd *unstructured.Unstructured) string {
+	if strategy, _, _ := unstructured.NestedString(crd.Object, "spec", "conversion", "strategy"); strategy != "Webhook" {
+		return ""
+	}
+	namespace, _, _ := unstructured.NestedString(crd.Object, "spec", "conversion", "webhook", "clientConfig", "service", "namespace")
+	return namespace
+}
+
+// enableConversion configures the operator webhook to convert tests between the API versions of the CRD and starts
+// serving all versions. Without the webhook only the v1alpha1 storage version is served.
+func enableConversion(restClient rest.Interface, crd *unstructured.Unstructured, namespace string, caBundle []byte) error {
+	conversion := map[string]interface{}{
+		"strategy": "Webhook",
+		"webhook": map[string]interface{}{
+			"clientConfig": map[string]interface{}{
+				"service": map[string]interface{}{
+					"namespace": namespace,
+					"name":      WebhookServiceName,
+					"path":      webhookConvert,
+				},
+				"caBundle": base64.StdEncoding.EncodeToString(caBundle),
+			},
+			"conversionReviewVersions": []interface{}{"v1beta1"},
+		},
+	}
+	if err := unstructured.SetNestedField(crd.Object, conversion, "spec", "conversion"); err != nil {
+		return err
+	}
+
+	if err := setServedVersions(crd, func(string) bool { return true }); err != nil {
+		return err
+	}
+
+	return updateCRD(restClient, crd)
+}
+
+// webhookCertificate reuses the serving certificate stored in the webhook secret as long as it is still valid.
+// Otherwise a new self signed certificate is created.
+func webhookCertificate(ctx context.Context, c client.Client, namespace string, dnsNames []string) (*certificate.Bundle, error) {
+	secret := corev1.Secret{}
+	err := c.Get(ctx, k8sclient.ObjectKey{Namespace: namespace, Name: WebhookSecretName}, &secret)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if err == nil {
+		bundle := certificate.Bundle{
+			CA:   secret.Data["ca.crt"],
+			Cert: secret.Data[corev1.TLSCertKey],
+			Key:  secret.Data[corev1.TLSPrivateKeyKey],
+		}
+		// renew certificates that expire within the next month
+		if bundle.Verify(dnsNames, time.Now().Add(30*24*time.Hour)) == nil {
+			return &bundle, nil
+		}
+	}
+
+	return certificate.NewBundle(WebhookServiceName, dnsNames, webhookValidity)
+}
+
+// labelWebhookNamespace labels the operator namespace so the webhooks of a namespaced operator only handle its own tests
+func labelWebhookNamespace(ctx context.Context, c client.Client, namespace string) error {
+	ns := corev1.Namespace{}
+	if err := c.Get(ctx, k8sclient.ObjectKey{Name: namespace}, &ns); err != nil {
+		return err
+	}
+
+	if ns.Labels[WebhookNamespaceLabel] == namespace {
+		return nil
+	}
+	if ns.Labels == nil {
+		ns.Labels = make(map[string]string)
+	}
+	ns.Labels[WebhookNamespaceLabel] = namespace
+	return c.Update(ctx, &ns)
+}
+
+// webhookCustomizer mounts the serving certificate into the operator and exposes the webhook port
+func webhookCustomizer(object runtime.Object) runtime.Object {
+	if deployment, ok := object.(*appsv1.Deployment); ok {
+		deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: "webhook-cert",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: WebhookSecretName,
+				},
+			},
+		})
+
+		for i := range deployment.Spec.Template.Spec.Containers {
+			container := &deployment.Spec.Template.Spec.Containers[i]
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      "webhook-cert",
+				MountPath: webhookCertDir,
+				ReadOnly:  true,
+			})
+			container.Ports = append(container.Ports, corev1.ContainerPort{
+				Name:          "webhook",
+				ContainerPort: webhookPort,
+				Protocol:      corev1.ProtocolTCP,
+			})
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name:  "YAKS_WEBHOOK_CERT_DIR",
+				Value: webhookCertDir,
+			})
+		}
+	}
+	return object
+}
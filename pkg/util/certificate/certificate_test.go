@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBundle(t *testing.T) {
+	names := []string{"yaks-webhook.test.svc", "yaks-webhook.test.svc.cluster.local"}
+	bundle, err := NewBundle("yaks-webhook", names, 24*time.Hour)
+	assert.Nil(t, err)
+
+	assert.Nil(t, bundle.Verify(names, time.Now()))
+	assert.NotNil(t, bundle.Verify([]string{"yaks-webhook.other.svc"}, time.Now()))
+	assert.NotNil(t, bundle.Verify(names, time.Now().Add(48*time.Hour)))
+
+	other, err := NewBundle("yaks-webhook", names, 24*time.Hour)
+	assert.Nil(t, err)
+	assert.NotNil(t, (&Bundle{CA: other.CA, Cert: bundle.Cert, Key: bundle.Key}).Verify(names, time.Now()))
+	assert.NotNil(t, (&Bundle{CA: bundle.CA, Cert: bundle.Cert, Key: other.Key}).Verify(names, time.Now()))
+	assert.NotNil(t, (&Bundle{}).Verify(names, time.Now()))
+}
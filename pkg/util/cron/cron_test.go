@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		spec     string
+		from     string
+		expected string
+	}{
+		{"* * * * *", "2020-03-10 10:15", "2020-03-10 10:16"},
+		{"*/15 * * * *", "2020-03-10 10:15", "2020-03-10 10:30"},
+		{"5/20 * * * *", "2020-03-10 10:46", "2020-03-10 11:05"},
+		{"0 9-17/4 * * *", "2020-03-10 13:00", "2020-03-10 17:00"},
+		{"30 2 * * *", "2020-03-10 10:15", "2020-03-11 02:30"},
+		{"0 0 1 * *", "2020-12-15 10:15", "2021-01-01 00:00"},
+		{"0 0 * * mon,fri", "2020-03-10 10:15", "2020-03-13 00:00"},
+		{"0 0 * * 7", "2020-03-10 10:15", "2020-03-15 00:00"},
+		{"0 0 13 * fri", "2020-03-10 10:15", "2020-03-13 00:00"},
+		{"0 0 29 feb *", "2020-03-01 00:00", "2024-02-29 00:00"},
+		{"@hourly", "2020-03-10 10:15", "2020-03-10 11:00"},
+		{"@weekly", "2020-03-10 10:15", "2020-03-15 00:00"},
+		{"@yearly", "2020-03-10 10:15", "2021-01-01 00:00"},
+	}
+
+	for _, test := range tests {
+		s, err := Parse(test.spec)
+		assert.Nil(t, err, test.spec)
+		assert.Equal(t, date(test.expected), s.Next(date(test.from)), test.spec)
+	}
+}
+
+func TestNextNoMatch(t *testing.T) {
+	s, err := Parse("0 0 30 feb *")
+	assert.Nil(t, err)
+	assert.True(t, s.Next(date("2020-03-10 10:15")).IsZero())
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"1-2-3 * * * *",
+		"@every 5m",
+	} {
+		_, err := Parse(spec)
+		assert.NotNil(t, err, spec)
+	}
+}
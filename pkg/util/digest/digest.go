@@ -20,6 +20,7 @@ package digest
 import (
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 
 	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
 	"github.com/citrusframework/yaks/version"
@@ -43,6 +44,73 @@ func ComputeForTest(test *v1alpha1.Test) (string, error) {
 		return "", err
 	}
 
+	// Additional sources and resources are relevant
+	for _, source := range test.Spec.Sources {
+		if _, err := hash.Write([]byte(source.Name)); err != nil {
+			return "", err
+		}
+		if _, err := hash.Write([]byte(source.Content)); err != nil {
+			return "", err
+		}
+	}
+	// Content stored in config map parts
+	partSpecs := []interface{}{test.Spec.Source.Parts, test.Spec.Settings.Parts}
+	for _, source := range test.Spec.Sources {
+		partSpecs = append(partSpecs, source.Parts)
+	}
+	parts, err := json.Marshal(partSpecs)
+	if err != nil {
+		return "", err
+	}
+	if _, err := hash.Write(parts); err != nil {
+		return "", err
+	}
+	resources, err := json.Marshal(test.Spec.Resources)
+	if err != nil {
+		return "", err
+	}
+	if _, err := hash.Write(resources); err != nil {
+		return "", err
+	}
+
+	// Dependencies and repositories are relevant
+	if _, err := hash.Write([]byte(test.Spec.Settings.Content)); err != nil {
+		return "", err
+	}
+	for _, dependency := range test.Spec.Dependencies {
+		if _, err := hash.Write([]byte(dependency.String())); err != nil {
+			return "", err
+		}
+	}
+	for _, repository := range test.Spec.Repositories {
+		if _, err := hash.Write([]byte(repository.ID + "=" + repository.URL)); err != nil {
+			return "", err
+		}
+	}
+
+	// Environment settings are relevant
+	for _, value := range test.Spec.Env {
+		if _, err := hash.Write([]byte(value)); err != nil {
+			return "", err
+		}
+	}
+	env, err := json.Marshal([]interface{}{test.Spec.EnvVars, test.Spec.EnvFrom})
+	if err != nil {
+		return "", err
+	}
+	if _, err := hash.Write(env); err != nil {
+		return "", err
+	}
+
+	// Runtime settings are relevant
+	runtime, err := json.Marshal(test.Spec.Runtime)
+	if err != nil {
+		return "", err
+	}
+	if _, err := hash.Write(runtime); err != nil {
+		return "", err
+	}
+
 	// Add a letter at the beginning and use URL safe encoding
 	digest := "v" + base64.RawURLEncoding.EncodeToString(hash.Sum(nil))
 	return digest, nil
@@ -97,3 +97,33 @@ func SetValFrom(vars *[]corev1.EnvVar, name string, path string) {
 		})
 	}
 }
+
+// SetValFromSecret --
+func SetValFromSecret(vars *[]corev1.EnvVar, name string, secret string, key string) {
+	SetVar(vars, corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secret,
+				},
+				Key: key,
+			},
+		},
+	})
+}
+
+// SetValFromConfigMap --
+func SetValFromConfigMap(vars *[]corev1.EnvVar, name string, configMap string, key string) {
+	SetVar(vars, corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: configMap,
+				},
+				Key: key,
+			},
+		},
+	})
+}
@@ -101,3 +101,30 @@ func TestSetEnvVar(t *testing.T) {
 	assert.NotNil(t, ev.ValueFrom)
 	assert.Equal(t, "metadata.namespace", ev.ValueFrom.FieldRef.FieldPath)
 }
+
+func TestSetEnvVarFromReference(t *testing.T) {
+	vars := []corev1.EnvVar{
+		{
+			Name:  "MyEnv",
+			Value: "MyValue",
+		},
+	}
+
+	SetValFromSecret(&vars, "MyEnv", "my-secret", "password")
+
+	ev := Get(vars, "MyEnv")
+	assert.NotNil(t, ev)
+	assert.Equal(t, "", ev.Value)
+	assert.NotNil(t, ev.ValueFrom)
+	assert.Equal(t, "my-secret", ev.ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "password", ev.ValueFrom.SecretKeyRef.Key)
+
+	SetValFromConfigMap(&vars, "MyEnv", "my-config", "level")
+
+	assert.Len(t, vars, 1)
+	ev = Get(vars, "MyEnv")
+	assert.NotNil(t, ev)
+	assert.Nil(t, ev.ValueFrom.SecretKeyRef)
+	assert.Equal(t, "my-config", ev.ValueFrom.ConfigMapKeyRef.Name)
+	assert.Equal(t, "level", ev.ValueFrom.ConfigMapKeyRef.Key)
+}
@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gherkin
+
+import (
+	"strings"
+)
+
+var scenarioKeywords = []string{"Scenario:", "Scenario Outline:", "Scenario Template:", "Example:"}
+
+// ScenarioLines returns the line numbers of all scenarios in the given feature. Cucumber accepts these lines as filter
+// in the format uri:line in order to run single scenarios of a feature. Scenario outlines are returned as a single
+// scenario that runs all of its examples.
+func ScenarioLines(feature string) []int {
+	lines := make([]int, 0)
+	docString := ""
+	for i, line := range strings.Split(feature, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if docString != "" {
+			if strings.HasPrefix(trimmed, docString) {
+				docString = ""
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, `"""`) || strings.HasPrefix(trimmed, "```") {
+			docString = trimmed[:3]
+			continue
+		}
+
+		for _, keyword := range scenarioKeywords {
+			if strings.HasPrefix(trimmed, keyword) {
+				lines = append(lines, i+1)
+				break
+			}
+		}
+	}
+	return lines
+}
@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gherkin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const feature = `Feature: Orders
+
+  Background:
+    Given URL: http://orders
+
+  Scenario: Create order
+    When send POST /orders
+    """
+    Scenario: not a scenario
+    """
+    Then receive HTTP 201 Created
+
+  @slow
+  Scenario Outline: Get order <id>
+    When send GET /orders/<id>
+    Then receive HTTP 200 OK
+
+    Examples:
+    | id |
+    | 1  |
+    | 2  |
+
+  Example: Delete order
+    When send DELETE /orders/1
+`
+
+func TestScenarioLines(t *testing.T) {
+	assert.Equal(t, []int{6, 14, 23}, ScenarioLines(feature))
+	assert.Empty(t, ScenarioLines("Feature: Empty\n"))
+}
@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package glob
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const anyDirs = "**"
+
+// HasMeta returns true if the given path contains any of the glob special characters
+func HasMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// Glob returns the names of all files matching given pattern. In addition to the
+// patterns supported by filepath.Match the pattern may use "**" as a path segment
+// in order to match any number of nested directories. Patterns match files only,
+// so that a file is never matched together with its directory.
+func Glob(pattern string) ([]string, error) {
+	if !strings.Contains(pattern, anyDirs) {
+		return globFiles(pattern)
+	}
+
+	matches := make([]string, 0)
+	root := staticPrefix(pattern)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		matched, err := Match(pattern, path)
+		if err != nil {
+			return err
+		}
+		if matched {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+
+	sort.Strings(matches)
+	return matches, err
+}
+
+// globFiles returns the files matching the given pattern, directories are skipped
+func globFiles(pattern string) ([]string, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			matches = append(matches, path)
+		}
+	}
+	return matches, nil
+}
+
+// Match reports whether name matches the given pattern. Pattern segments "**" match
+// zero or more path segments.
+func Match(pattern, name string) (bool, error) {
+	return matchSegments(split(pattern), split(name))
+}
+
+func matchSegments(pattern []string, name []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == anyDirs {
+			// try to consume as many name segments as required by the rest of the pattern
+			for i := 0; i <= len(name); i++ {
+				if matched, err := matchSegments(pattern[1:], name[i:]); err != nil || matched {
+					return matched, err
+				}
+			}
+			return false, nil
+		}
+
+		if len(name) == 0 {
+			return false, nil
+		}
+
+		if matched, err := filepath.Match(pattern[0], name[0]); err != nil || !matched {
+			return false, err
+		}
+
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+
+	return len(name) == 0, nil
+}
+
+func staticPrefix(pattern string) string {
+	segments := split(pattern)
+	prefix := make([]string, 0)
+	for _, segment := range segments {
+		if HasMeta(segment) {
+			break
+		}
+		prefix = append(prefix, segment)
+	}
+
+	if len(prefix) == 0 {
+		if filepath.IsAbs(pattern) {
+			return string(filepath.Separator)
+		}
+		return "."
+	}
+
+	root := filepath.Join(prefix...)
+	if filepath.IsAbs(pattern) {
+		root = string(filepath.Separator) + root
+	}
+	return root
+}
+
+func split(path string) []string {
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(filepath.ToSlash(filepath.Clean(path)), "/") {
+		if segment != "" && segment != "." {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package glob
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	matched, err := Match("suites/**/*.feature", "suites/a.feature")
+	assert.Nil(t, err)
+	assert.True(t, matched)
+
+	matched, err = Match("suites/**/*.feature", "suites/foo/bar/b.feature")
+	assert.Nil(t, err)
+	assert.True(t, matched)
+
+	matched, err = Match("suites/**/*.feature", "suites/foo/yaks-config.yaml")
+	assert.Nil(t, err)
+	assert.False(t, matched)
+
+	matched, err = Match("suites/*.feature", "suites/foo/b.feature")
+	assert.Nil(t, err)
+	assert.False(t, matched)
+
+	matched, err = Match("**", "suites/foo/b.feature")
+	assert.Nil(t, err)
+	assert.True(t, matched)
+}
+
+func TestGlob(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yaks-glob-*")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "suites", "sub"), 0755))
+	for _, name := range []string{"suites/a.feature", "suites/sub/b.feature", "suites/sub/yaks-config.yaml"} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte{}, 0644))
+	}
+
+	matches, err := Glob(filepath.Join(dir, "suites", "**", "*.feature"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "suites", "a.feature"),
+		filepath.Join(dir, "suites", "sub", "b.feature"),
+	}, matches)
+
+	matches, err = Glob(filepath.Join(dir, "suites", "**"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "suites", "a.feature"),
+		filepath.Join(dir, "suites", "sub", "b.feature"),
+		filepath.Join(dir, "suites", "sub", "yaks-config.yaml"),
+	}, matches)
+
+	matches, err = Glob(filepath.Join(dir, "suites", "*.feature"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "suites", "a.feature")}, matches)
+
+	matches, err = Glob(filepath.Join(dir, "suites", "*"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "suites", "a.feature")}, matches)
+
+	matches, err = Glob(filepath.Join(dir, "suites", "sub", "*"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "suites", "sub", "b.feature"),
+		filepath.Join(dir, "suites", "sub", "yaks-config.yaml"),
+	}, matches)
+}
@@ -41,6 +41,16 @@ func ReplaceResources(ctx context.Context, c client.Client, objects []runtime.Ob
 	return nil
 }
 
+// ReplaceResourcesInNamespace replaces the given list of resources in the given namespace
+func ReplaceResourcesInNamespace(ctx context.Context, c client.Client, namespace string, objects []runtime.Object) error {
+	for _, object := range objects {
+		if meta, ok := object.(metav1.Object); ok {
+			meta.SetNamespace(namespace)
+		}
+	}
+	return ReplaceResources(ctx, c, objects)
+}
+
 // ReplaceResource allows to completely replace a resource on Kubernetes, taking care of immutable fields and resource versions
 func ReplaceResource(ctx context.Context, c client.Client, res runtime.Object) error {
 	err := c.Create(ctx, res)
@@ -28,6 +28,7 @@ import (
 
 var disallowedChars = regexp.MustCompile(`[^a-z0-9-]`)
 var disallowedCharsInFile = regexp.MustCompile(`[^A-Za-z0-9-_.]`)
+var disallowedCharsInKey = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
 
 // SanitizeName sanitizes the given name to be compatible with k8s
 func SanitizeName(name string) string {
@@ -46,6 +47,11 @@ func SanitizeFileName(name string) string {
 	return name
 }
 
+// SanitizeConfigMapKey turns the given relative file path into a valid config map key
+func SanitizeConfigMapKey(name string) string {
+	return disallowedCharsInKey.ReplaceAllString(name, "_")
+}
+
 // SanitizeLabel sanitizes the given name to be compatible with k8s
 func SanitizeLabel(name string) string {
 	name = strings.ToLower(name)
@@ -99,6 +99,26 @@ func (l Logger) ForTest(target *v1alpha1.Test) Logger {
 	)
 }
 
+// ForTestSuite --
+func (l Logger) ForTestSuite(target *v1alpha1.TestSuite) Logger {
+	return l.WithValues(
+		"api-version", target.APIVersion,
+		"kind", target.Kind,
+		"ns", target.Namespace,
+		"name", target.Name,
+	)
+}
+
+// ForTestSchedule --
+func (l Logger) ForTestSchedule(target *v1alpha1.TestSchedule) Logger {
+	return l.WithValues(
+		"api-version", target.APIVersion,
+		"kind", target.Kind,
+		"ns", target.Namespace,
+		"name", target.Name,
+	)
+}
+
 // ***********************************
 //
 // Helpers
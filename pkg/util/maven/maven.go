@@ -0,0 +1,180 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"gopkg.in/yaml.v2"
+)
+
+// coordinatePattern is the format of Maven coordinates accepted by the test runtime
+var coordinatePattern = regexp.MustCompile(`^([^:]+?):([^:]+?):([@.0-9][^:]+?)$`)
+
+const dependencyPropertyPrefix = "yaks.dependency."
+
+// ParseDependency reads a dependency from Maven coordinates in the format groupId:artifactId:version
+func ParseDependency(coordinates string) (v1alpha1.DependencySpec, error) {
+	match := coordinatePattern.FindStringSubmatch(strings.TrimSpace(coordinates))
+	if match == nil {
+		return v1alpha1.DependencySpec{}, fmt.Errorf("malformed dependency '%s', expected groupId:artifactId:version", coordinates)
+	}
+
+	return v1alpha1.DependencySpec{
+		GroupID:    match[1],
+		ArtifactID: match[2],
+		Version:    match[3],
+	}, nil
+}
+
+// ValidateDependency checks that the dependency has valid Maven coordinates
+func ValidateDependency(dependency v1alpha1.DependencySpec) error {
+	if dependency.GroupID == "" || dependency.ArtifactID == "" || dependency.Version == "" ||
+		!coordinatePattern.MatchString(dependency.String()) {
+		return fmt.Errorf("malformed dependency '%s', expected groupId, artifactId and version", dependency.String())
+	}
+	return nil
+}
+
+// ValidateRepository checks that the repository has an id and a valid URL
+func ValidateRepository(repository v1alpha1.RepositorySpec) error {
+	if repository.ID == "" || strings.ContainsAny(repository.ID, "=,") {
+		return fmt.Errorf("invalid repository id '%s'", repository.ID)
+	}
+
+	if u, err := url.Parse(repository.URL); err != nil || u.Scheme == "" || strings.Contains(repository.URL, ",") {
+		return fmt.Errorf("invalid url '%s' for repository '%s'", repository.URL, repository.ID)
+	}
+	return nil
+}
+
+// ParseRepository reads a repository given in the format id=url
+func ParseRepository(value string) (v1alpha1.RepositorySpec, error) {
+	pair := strings.SplitN(value, "=", 2)
+	if len(pair) != 2 {
+		return v1alpha1.RepositorySpec{}, fmt.Errorf("malformed repository '%s', expected id=url", value)
+	}
+
+	repository := v1alpha1.RepositorySpec{
+		ID:  strings.TrimSpace(pair[0]),
+		URL: strings.TrimSpace(pair[1]),
+	}
+	return repository, ValidateRepository(repository)
+}
+
+// MergeDependencies adds the given dependencies to the list of known dependencies. Dependencies that are already known
+// are skipped, the same artifact with a different version is reported as conflict.
+func MergeDependencies(known []v1alpha1.DependencySpec, dependencies ...v1alpha1.DependencySpec) ([]v1alpha1.DependencySpec, error) {
+	versions := make(map[string]string)
+	for _, dependency := range known {
+		versions[dependency.GroupID+":"+dependency.ArtifactID] = dependency.Version
+	}
+
+	merged := known
+	for _, dependency := range dependencies {
+		if err := ValidateDependency(dependency); err != nil {
+			return nil, err
+		}
+
+		key := dependency.GroupID + ":" + dependency.ArtifactID
+		if version, ok := versions[key]; ok {
+			if version != dependency.Version {
+				return nil, fmt.Errorf("conflicting versions %s and %s for dependency %s", version, dependency.Version, key)
+			}
+			continue
+		}
+
+		versions[key] = dependency.Version
+		merged = append(merged, dependency)
+	}
+
+	return merged, nil
+}
+
+// LoadSettingsDependencies reads the dependencies from a runtime settings file. The file format is chosen by the file
+// extension (yaml, json or properties) in the same way as the test runtime does.
+func LoadSettingsDependencies(fileName string, content string) ([]v1alpha1.DependencySpec, error) {
+	dependencies := make([]v1alpha1.DependencySpec, 0)
+
+	switch strings.TrimPrefix(path.Ext(fileName), ".") {
+	case "yaml", "yml":
+		var settings struct {
+			Dependencies []struct {
+				Dependency map[string]string `yaml:"dependency"`
+			} `yaml:"dependencies"`
+		}
+		if err := yaml.Unmarshal([]byte(content), &settings); err != nil {
+			return nil, fmt.Errorf("invalid settings file %s: %v", fileName, err)
+		}
+		for _, entry := range settings.Dependencies {
+			if entry.Dependency == nil {
+				continue
+			}
+			dependencies = append(dependencies, v1alpha1.DependencySpec{
+				GroupID:    entry.Dependency["groupId"],
+				ArtifactID: entry.Dependency["artifactId"],
+				Version:    entry.Dependency["version"],
+			})
+		}
+	case "json":
+		var settings struct {
+			Dependencies []v1alpha1.DependencySpec `json:"dependencies"`
+		}
+		if err := json.Unmarshal([]byte(content), &settings); err != nil {
+			return nil, fmt.Errorf("invalid settings file %s: %v", fileName, err)
+		}
+		dependencies = append(dependencies, settings.Dependencies...)
+	default:
+		scanner := bufio.NewScanner(strings.NewReader(content))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+				continue
+			}
+
+			pair := strings.SplitN(line, "=", 2)
+			if len(pair) != 2 {
+				pair = strings.SplitN(line, ":", 2)
+			}
+			if len(pair) != 2 || !strings.HasPrefix(strings.TrimSpace(pair[0]), dependencyPropertyPrefix) {
+				continue
+			}
+
+			dependency, err := ParseDependency(pair[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid settings file %s: %v", fileName, err)
+			}
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	for _, dependency := range dependencies {
+		if err := ValidateDependency(dependency); err != nil {
+			return nil, fmt.Errorf("invalid settings file %s: %v", fileName, err)
+		}
+	}
+
+	return dependencies, nil
+}
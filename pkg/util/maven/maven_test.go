@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"testing"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDependency(t *testing.T) {
+	dependency, err := ParseDependency("org.foo:foo-artifact:1.0.0")
+	assert.Nil(t, err)
+	assert.Equal(t, v1alpha1.DependencySpec{GroupID: "org.foo", ArtifactID: "foo-artifact", Version: "1.0.0"}, dependency)
+
+	dependency, err = ParseDependency("org.foo:foo-artifact:@foo.version@")
+	assert.Nil(t, err)
+	assert.Equal(t, "@foo.version@", dependency.Version)
+
+	_, err = ParseDependency("org.foo:foo-artifact")
+	assert.NotNil(t, err)
+
+	_, err = ParseDependency("org.foo:foo-artifact:latest")
+	assert.NotNil(t, err)
+}
+
+func TestMergeDependencies(t *testing.T) {
+	foo := v1alpha1.DependencySpec{GroupID: "org.foo", ArtifactID: "foo-artifact", Version: "1.0.0"}
+	bar := v1alpha1.DependencySpec{GroupID: "org.bar", ArtifactID: "bar-artifact", Version: "1.5.0"}
+
+	merged, err := MergeDependencies([]v1alpha1.DependencySpec{foo}, foo, bar)
+	assert.Nil(t, err)
+	assert.Equal(t, []v1alpha1.DependencySpec{foo, bar}, merged)
+
+	fooUpdate := foo
+	fooUpdate.Version = "2.0.0"
+	_, err = MergeDependencies([]v1alpha1.DependencySpec{foo}, fooUpdate)
+	assert.NotNil(t, err)
+
+	_, err = MergeDependencies(nil, v1alpha1.DependencySpec{GroupID: "org.foo"})
+	assert.NotNil(t, err)
+}
+
+func TestLoadSettingsDependencies(t *testing.T) {
+	foo := v1alpha1.DependencySpec{GroupID: "org.foo", ArtifactID: "foo-artifact", Version: "1.0.0"}
+	bar := v1alpha1.DependencySpec{GroupID: "org.bar", ArtifactID: "bar-artifact", Version: "1.5.0"}
+
+	dependencies, err := LoadSettingsDependencies("yaks.settings.yaml", `
+dependencies:
+  - dependency:
+      groupId: org.foo
+      artifactId: foo-artifact
+      version: 1.0.0
+  - dependency:
+      groupId: org.bar
+      artifactId: bar-artifact
+      version: 1.5.0
+`)
+	assert.Nil(t, err)
+	assert.Equal(t, []v1alpha1.DependencySpec{foo, bar}, dependencies)
+
+	dependencies, err = LoadSettingsDependencies("yaks.settings.json", `{
+  "dependencies": [
+    { "groupId": "org.foo", "artifactId": "foo-artifact", "version": "1.0.0" },
+    { "groupId": "org.bar", "artifactId": "bar-artifact", "version": "1.5.0" }
+  ]
+}`)
+	assert.Nil(t, err)
+	assert.Equal(t, []v1alpha1.DependencySpec{foo, bar}, dependencies)
+
+	dependencies, err = LoadSettingsDependencies("yaks.properties", `
+# test dependencies
+yaks.dependency.foo=org.foo:foo-artifact:1.0.0
+yaks.dependency.bar=org.bar:bar-artifact:1.5.0
+`)
+	assert.Nil(t, err)
+	assert.Equal(t, []v1alpha1.DependencySpec{foo, bar}, dependencies)
+
+	_, err = LoadSettingsDependencies("yaks.properties", "yaks.dependency.foo=org.foo:foo-artifact")
+	assert.NotNil(t, err)
+}
+
+func TestParseRepository(t *testing.T) {
+	repository, err := ParseRepository("jboss-ea=https://repository.jboss.org/nexus/content/groups/ea/")
+	assert.Nil(t, err)
+	assert.Equal(t, "jboss-ea", repository.ID)
+	assert.Equal(t, "https://repository.jboss.org/nexus/content/groups/ea/", repository.URL)
+
+	_, err = ParseRepository("https://repository.jboss.org/nexus/content/groups/ea/")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,189 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SettingsFileName is the default key of the settings.xml in a config map or secret
+	SettingsFileName = "settings.xml"
+
+	// MirrorID is the id of the mirror repository in the generated settings
+	MirrorID = "yaks-mirror"
+
+	// MirrorUsernameEnv holds the mirror username read from a secret
+	MirrorUsernameEnv = "YAKS_MAVEN_MIRROR_USERNAME"
+	// MirrorPasswordEnv holds the mirror password read from a secret
+	MirrorPasswordEnv = "YAKS_MAVEN_MIRROR_PASSWORD"
+)
+
+// ParseSettingsReference reads a reference to a Maven settings.xml in the format configmap:name[/key] or secret:name[/key].
+// The key defaults to settings.xml.
+func ParseSettingsReference(reference string) (*v1alpha1.MavenSettingsSpec, error) {
+	pair := strings.SplitN(strings.TrimSpace(reference), ":", 2)
+	if len(pair) != 2 || pair[1] == "" {
+		return nil, fmt.Errorf("malformed Maven settings reference '%s', expected configmap:name[/key] or secret:name[/key]", reference)
+	}
+
+	name := pair[1]
+	key := SettingsFileName
+	if i := strings.Index(name, "/"); i >= 0 {
+		name, key = name[:i], name[i+1:]
+	}
+	if name == "" || key == "" {
+		return nil, fmt.Errorf("malformed Maven settings reference '%s', expected configmap:name[/key] or secret:name[/key]", reference)
+	}
+
+	switch strings.ToLower(pair[0]) {
+	case "configmap":
+		return &v1alpha1.MavenSettingsSpec{
+			ConfigMap: &v1.ConfigMapKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: name},
+				Key:                  key,
+			},
+		}, nil
+	case "secret":
+		return &v1alpha1.MavenSettingsSpec{
+			Secret: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: name},
+				Key:                  key,
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported Maven settings reference type '%s', expected configmap or secret", pair[0])
+	}
+}
+
+// MirrorCredentials returns the selectors for the keys "username" and "password" of the given secret
+func MirrorCredentials(secret string) (*v1.SecretKeySelector, *v1.SecretKeySelector) {
+	username := v1.SecretKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: secret},
+		Key:                  "username",
+	}
+	password := v1.SecretKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: secret},
+		Key:                  "password",
+	}
+	return &username, &password
+}
+
+// ValidateSettings checks that the Maven settings either reference a settings.xml or a mirror repository
+func ValidateSettings(spec v1alpha1.MavenSpec) error {
+	if spec.Settings != nil {
+		if spec.Mirror != "" {
+			return fmt.Errorf("maven settings must not define both a settings.xml and a mirror")
+		}
+		if spec.Settings.ConfigMap != nil && spec.Settings.Secret != nil {
+			return fmt.Errorf("maven settings must not reference both a config map and a secret")
+		}
+		if spec.Settings.ConfigMap != nil && (spec.Settings.ConfigMap.Name == "" || spec.Settings.ConfigMap.Key == "") {
+			return fmt.Errorf("maven settings must reference a config map name and key")
+		}
+		if spec.Settings.Secret != nil && (spec.Settings.Secret.Name == "" || spec.Settings.Secret.Key == "") {
+			return fmt.Errorf("maven settings must reference a secret name and key")
+		}
+		if spec.Settings.ConfigMap == nil && spec.Settings.Secret == nil {
+			return fmt.Errorf("maven settings must reference a config map or a secret")
+		}
+	}
+
+	if spec.Mirror != "" {
+		if u, err := url.Parse(spec.Mirror); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("malformed Maven mirror url '%s'", spec.Mirror)
+		}
+	}
+
+	for _, credential := range []*v1.SecretKeySelector{spec.Username, spec.Password} {
+		if credential != nil && (credential.Name == "" || credential.Key == "") {
+			return fmt.Errorf("maven mirror credentials must reference a secret name and key")
+		}
+	}
+
+	return nil
+}
+
+// NewMirrorSettings generates a settings.xml that redirects all repository requests to the given mirror. The mirror
+// credentials are resolved from environment variables so they never get stored in the generated settings.
+func NewMirrorSettings(spec v1alpha1.MavenSpec) (string, error) {
+	var mirror bytes.Buffer
+	if err := xml.EscapeText(&mirror, []byte(spec.Mirror)); err != nil {
+		return "", err
+	}
+
+	var server string
+	if spec.Username != nil || spec.Password != nil {
+		server = fmt.Sprintf(`
+        <server>
+            <id>%s</id>
+            <username>${env.%s}</username>
+            <password>${env.%s}</password>
+        </server>`, MirrorID, MirrorUsernameEnv, MirrorPasswordEnv)
+	}
+
+	return fmt.Sprintf(`<settings xmlns="http://maven.apache.org/SETTINGS/1.0.0">
+
+    <servers>%s
+        <server>
+            <id>s3</id>
+            <username>${env.YAKS_S3_REPOSITORY_ACCESS_KEY}</username>
+            <password>${env.YAKS_S3_REPOSITORY_SECRET_KEY}</password>
+            <configuration>
+                <endpoint>${env.YAKS_S3_REPOSITORY_URL}</endpoint>
+                <pathStyleEnabled>true</pathStyleEnabled>
+            </configuration>
+        </server>
+    </servers>
+
+    <mirrors>
+        <mirror>
+            <id>%s</id>
+            <mirrorOf>*,!s3</mirrorOf>
+            <url>%s</url>
+        </mirror>
+    </mirrors>
+
+    <profiles>
+        <profile>
+            <id>s3</id>
+            <activation>
+                <property>
+                    <name>env.YAKS_S3_REPOSITORY_URL</name>
+                </property>
+            </activation>
+            <repositories>
+                <repository>
+                    <id>s3</id>
+                    <name>Minio Server</name>
+                    <url>s3://${env.YAKS_S3_REPOSITORY_BUCKET}</url>
+                </repository>
+            </repositories>
+        </profile>
+    </profiles>
+
+</settings>
+`, server, MirrorID, mirror.String()), nil
+}
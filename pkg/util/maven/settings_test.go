@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSettingsReference(t *testing.T) {
+	settings, err := ParseSettingsReference("configmap:maven-settings")
+	assert.Nil(t, err)
+	assert.Nil(t, settings.Secret)
+	assert.Equal(t, "maven-settings", settings.ConfigMap.Name)
+	assert.Equal(t, SettingsFileName, settings.ConfigMap.Key)
+
+	settings, err = ParseSettingsReference("secret:maven-settings/custom.xml")
+	assert.Nil(t, err)
+	assert.Nil(t, settings.ConfigMap)
+	assert.Equal(t, "maven-settings", settings.Secret.Name)
+	assert.Equal(t, "custom.xml", settings.Secret.Key)
+
+	_, err = ParseSettingsReference("maven-settings")
+	assert.NotNil(t, err)
+
+	_, err = ParseSettingsReference("volume:maven-settings")
+	assert.NotNil(t, err)
+
+	_, err = ParseSettingsReference("secret:maven-settings/")
+	assert.NotNil(t, err)
+}
+
+func TestValidateSettings(t *testing.T) {
+	settings, _ := ParseSettingsReference("configmap:maven-settings")
+	username, password := MirrorCredentials("nexus")
+
+	assert.Nil(t, ValidateSettings(v1alpha1.MavenSpec{}))
+	assert.Nil(t, ValidateSettings(v1alpha1.MavenSpec{Settings: settings, Username: username, Password: password}))
+	assert.Nil(t, ValidateSettings(v1alpha1.MavenSpec{Mirror: "https://nexus.example.com/repository/maven-public/"}))
+
+	assert.NotNil(t, ValidateSettings(v1alpha1.MavenSpec{Settings: settings, Mirror: "https://nexus.example.com"}))
+	assert.NotNil(t, ValidateSettings(v1alpha1.MavenSpec{Mirror: "nexus"}))
+	assert.NotNil(t, ValidateSettings(v1alpha1.MavenSpec{Settings: &v1alpha1.MavenSettingsSpec{}}))
+}
+
+func TestNewMirrorSettings(t *testing.T) {
+	settings, err := NewMirrorSettings(v1alpha1.MavenSpec{Mirror: "https://nexus.example.com/maven?group=public&all=true"})
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(settings, "<url>https://nexus.example.com/maven?group=public&amp;all=true</url>"))
+	assert.False(t, strings.Contains(settings, MirrorUsernameEnv))
+
+	username, password := MirrorCredentials("nexus")
+	settings, err = NewMirrorSettings(v1alpha1.MavenSpec{Mirror: "https://nexus.example.com", Username: username, Password: password})
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(settings, "<username>${env."+MirrorUsernameEnv+"}</username>"))
+	assert.True(t, strings.Contains(settings, "<password>${env."+MirrorPasswordEnv+"}</password>"))
+}
@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Shard is a slice of the tests of a run, the index starts with 1
+type Shard struct {
+	Index int
+	Total int
+}
+
+// String returns the shard in the format index/total
+func (s Shard) String() string {
+	return fmt.Sprintf("%d/%d", s.Index, s.Total)
+}
+
+// Parse reads a shard in the format index/total, e.g. 2/5
+func Parse(value string) (Shard, error) {
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return Shard{}, fmt.Errorf("invalid shard '%s', expected index/total such as 2/5", value)
+	}
+
+	index, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return Shard{}, fmt.Errorf("invalid shard '%s', expected index/total such as 2/5", value)
+	}
+	total, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return Shard{}, fmt.Errorf("invalid shard '%s', expected index/total such as 2/5", value)
+	}
+
+	if total < 1 || index < 1 || index > total {
+		return Shard{}, fmt.Errorf("invalid shard '%s', the index must be between 1 and the total number of shards", value)
+	}
+
+	return Shard{Index: index, Total: total}, nil
+}
+
+// ByName selects the names that belong to the shard based on a hash of each name. The selection is stable as long as
+// the total number of shards does not change.
+func ByName(names []string, s Shard) []string {
+	selected := make([]string, 0)
+	for _, name := range names {
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(name))
+		if int(hash.Sum32()%uint32(s.Total)) == s.Index-1 {
+			selected = append(selected, name)
+		}
+	}
+	return selected
+}
+
+// ByDuration selects the names that belong to the shard so that all shards take about the same time. The longest
+// tests are distributed first, each to the shard with the least total duration so far. Names without a known duration
+// are assumed to take the average duration of the known ones.
+func ByDuration(names []string, durations map[string]time.Duration, s Shard) []string {
+	var known time.Duration
+	count := 0
+	for _, name := range names {
+		if duration, ok := durations[name]; ok {
+			known += duration
+			count++
+		}
+	}
+
+	average := time.Duration(0)
+	if count > 0 {
+		average = known / time.Duration(count)
+	}
+
+	durationOf := func(name string) time.Duration {
+		if duration, ok := durations[name]; ok {
+			return duration
+		}
+		return average
+	}
+
+	sorted := append([]string(nil), names...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if durationOf(sorted[i]) != durationOf(sorted[j]) {
+			return durationOf(sorted[i]) > durationOf(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+
+	totals := make([]time.Duration, s.Total)
+	assigned := make(map[string]bool)
+	for _, name := range sorted {
+		shard := 0
+		for i := range totals {
+			if totals[i] < totals[shard] {
+				shard = i
+			}
+		}
+		totals[shard] += durationOf(name)
+		if shard == s.Index-1 {
+			assigned[name] = true
+		}
+	}
+
+	// keep the original order of the names
+	selected := make([]string, 0, len(assigned))
+	for _, name := range names {
+		if assigned[name] {
+			selected = append(selected, name)
+		}
+	}
+	return selected
+}
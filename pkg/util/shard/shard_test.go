@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	s, err := Parse("2/5")
+	assert.Nil(t, err)
+	assert.Equal(t, Shard{Index: 2, Total: 5}, s)
+	assert.Equal(t, "2/5", s.String())
+
+	for _, value := range []string{"", "2", "a/5", "2/b", "0/5", "6/5", "1/0"} {
+		_, err := Parse(value)
+		assert.NotNil(t, err, value)
+	}
+}
+
+func TestByName(t *testing.T) {
+	names := make([]string, 0)
+	for i := 0; i < 50; i++ {
+		names = append(names, fmt.Sprintf("test-%d", i))
+	}
+
+	all := make(map[string]int)
+	for i := 1; i <= 3; i++ {
+		selected := ByName(names, Shard{Index: i, Total: 3})
+		assert.Equal(t, selected, ByName(names, Shard{Index: i, Total: 3}))
+		for _, name := range selected {
+			all[name]++
+		}
+	}
+
+	assert.Len(t, all, len(names))
+	for _, count := range all {
+		assert.Equal(t, 1, count)
+	}
+}
+
+func TestByDuration(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e"}
+	durations := map[string]time.Duration{
+		"a": 10 * time.Minute,
+		"b": 6 * time.Minute,
+		"c": 4 * time.Minute,
+		"d": 2 * time.Minute,
+	}
+
+	assert.Equal(t, []string{"a", "c"}, ByDuration(names, durations, Shard{Index: 1, Total: 2}))
+	assert.Equal(t, []string{"b", "d", "e"}, ByDuration(names, durations, Shard{Index: 2, Total: 2}))
+
+	assert.Equal(t, names, ByDuration(names, nil, Shard{Index: 1, Total: 1}))
+}
@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/citrusframework/yaks/pkg/apis/yaks/v1alpha1"
+	controller "github.com/citrusframework/yaks/pkg/controller/test"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+const (
+	// MutatePath is the path serving the defaulting webhook for tests
+	MutatePath = "/mutate-test"
+	// ValidatePath is the path serving the validating webhook for tests
+	ValidatePath = "/validate-test"
+	// ConvertPath is the path serving the conversion webhook between the test API versions
+	ConvertPath = "/convert"
+)
+
+var log = logf.Log.WithName("webhook")
+
+// Add registers the defaulting and validating webhooks for tests with the webhook server of the manager
+func Add(mgr manager.Manager) error {
+	server := mgr.GetWebhookServer()
+	server.Register(MutatePath, &webhook.Admission{Handler: &defaulter{}})
+	server.Register(ValidatePath, &webhook.Admission{Handler: &validator{}})
+	server.Register(ConvertPath, &conversion.Webhook{})
+	return nil
+}
+
+// defaulter fills in default values of the test spec such as the source language and the timeout
+type defaulter struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &defaulter{}
+
+// InjectDecoder injects the decoder
+func (d *defaulter) InjectDecoder(decoder *admission.Decoder) error {
+	d.decoder = decoder
+	return nil
+}
+
+// Handle handles the admission request
+func (d *defaulter) Handle(_ context.Context, req admission.Request) admission.Response {
+	test := v1alpha1.Test{}
+	if err := d.decoder.Decode(req, &test); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	controller.SetDefaults(&test)
+
+	marshaled, err := json.Marshal(&test)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// validator rejects tests with an invalid spec or dependencies on other tests that form a cycle
+type validator struct {
+	client  client.Client
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &validator{}
+var _ inject.Client = &validator{}
+
+// InjectClient injects the client
+func (v *validator) InjectClient(c client.Client) error {
+	v.client = c
+	return nil
+}
+
+// InjectDecoder injects the decoder
+func (v *validator) InjectDecoder(decoder *admission.Decoder) error {
+	v.decoder = decoder
+	return nil
+}
+
+// Handle handles the admission request
+func (v *validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1beta1.Create && req.Operation != admissionv1beta1.Update {
+		return admission.Allowed("")
+	}
+
+	test := v1alpha1.Test{}
+	if err := v.decoder.Decode(req, &test); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := controller.Validate(&test); err != nil {
+		log.Info("Rejecting invalid test", "namespace", req.Namespace, "name", req.Name, "reason", err.Error())
+		return admission.Denied(err.Error())
+	}
+
+	if len(test.Spec.DependsOn) > 0 && v.client != nil {
+		if test.Namespace == "" {
+			test.Namespace = req.Namespace
+		}
+		if err := controller.CheckDependencyCycle(ctx, v.client, &test); err != nil {
+			log.Info("Rejecting test with dependency cycle", "namespace", req.Namespace, "name", req.Name, "reason", err.Error())
+			return admission.Denied(err.Error())
+		}
+	}
+
+	return admission.Allowed("")
+}